@@ -4,30 +4,56 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
+// GetAccount retrieves the identity, tier and usage statistics
+// of the account associated with the API key.
+//
+// Unlike the internally cached identifiers, the account is fetched
+// on every call so that statistics are up to date.
+func (c *GofileClient) GetAccount(ctx context.Context) (_ Account, err error) {
+	defer c.observeOperation("GetAccount", time.Now(), &err)
+	getIdResp, err := c.getId(ctx)
+	if err != nil {
+		return Account{}, err
+	}
+	if getIdResp.Data.Id == "" {
+		return Account{}, fmt.Errorf("empty accountId error")
+	}
+	getAccountInfoResp, err := c.getAccountInfo(ctx, getIdResp.Data.Id)
+	if err != nil {
+		return Account{}, err
+	}
+
+	info := getAccountInfoResp.Data
+	account := Account{
+		Id:                     getIdResp.Data.Id,
+		Email:                  info.Email,
+		Tier:                   getIdResp.Data.Tier,
+		RootFolderId:           info.RootFolder,
+		FolderCount:            info.Stats.FolderCount,
+		FileCount:              info.Stats.FileCount,
+		Storage:                info.Stats.Storage,
+		TrafficDirectGenerated: info.Stats.TrafficDirectGenerated,
+		TrafficReqDownloaded:   info.Stats.TrafficReqDownloaded,
+		TrafficWebDownloaded:   info.Stats.TrafficWebDownloaded,
+	}
+	if account.Email == "" {
+		account.Email = getIdResp.Data.Email
+	}
+	return account, nil
+}
+
 // accountId resolves and caches the account ID associated with the API key.
 //
 // The value is fetched once and reused for subsequent calls.
 // The method is safe for concurrent use.
 func (c *GofileClient) accountId(ctx context.Context) (string, error) {
 	c.accountIdOnce.Do(func() {
-		req, err := c.createGetIdRequest(ctx)
-		if err != nil {
-			c.accountIdError = fmt.Errorf("creating 'getid' request: %w", err)
-			return
-		}
-		resp, err := c.do(req)
-		if err != nil {
-			c.accountIdError = fmt.Errorf("sending 'getid' request: %w", err)
-			return
-		}
-		defer resp.Body.Close()
-
-		var getIdResp getIdResponseData
-		err = json.NewDecoder(resp.Body).Decode(&getIdResp)
+		getIdResp, err := c.getId(ctx)
 		if err != nil {
-			c.accountIdError = fmt.Errorf("unmarshalling 'getid' response: %w", err)
+			c.accountIdError = err
 			return
 		}
 
@@ -53,22 +79,9 @@ func (c *GofileClient) rootFolderId(ctx context.Context) (string, error) {
 			return
 		}
 
-		req, err := c.createGetAccountInfoRequest(ctx, accountId)
-		if err != nil {
-			c.rootFolderIdError = fmt.Errorf("failed to create 'getAccountInfo' request: %w", err)
-			return
-		}
-		resp, err := c.do(req)
-		if err != nil {
-			c.rootFolderIdError = fmt.Errorf("failed to send 'getAccountInfo' request: %w", err)
-			return
-		}
-		defer resp.Body.Close()
-
-		var getAccountInfoResp getAccountInfoResponseData
-		err = json.NewDecoder(resp.Body).Decode(&getAccountInfoResp)
+		getAccountInfoResp, err := c.getAccountInfo(ctx, accountId)
 		if err != nil {
-			c.rootFolderIdError = fmt.Errorf("failed to unmarshal 'getAccountInfo' response: %w", err)
+			c.rootFolderIdError = err
 			return
 		}
 
@@ -81,3 +94,43 @@ func (c *GofileClient) rootFolderId(ctx context.Context) (string, error) {
 
 	return c.rootFolderIdCached, c.rootFolderIdError
 }
+
+// getId fetches the ID, tier and email of the account associated with the API key.
+func (c *GofileClient) getId(ctx context.Context) (getIdResponseData, error) {
+	req, err := c.createGetIdRequest(ctx)
+	if err != nil {
+		return getIdResponseData{}, fmt.Errorf("creating 'getid' request: %w", err)
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return getIdResponseData{}, fmt.Errorf("sending 'getid' request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var getIdResp getIdResponseData
+	err = json.NewDecoder(resp.Body).Decode(&getIdResp)
+	if err != nil {
+		return getIdResponseData{}, fmt.Errorf("unmarshalling 'getid' response: %w", err)
+	}
+	return getIdResp, nil
+}
+
+// getAccountInfo fetches the metadata and statistics of the specified account.
+func (c *GofileClient) getAccountInfo(ctx context.Context, accountId string) (getAccountInfoResponseData, error) {
+	req, err := c.createGetAccountInfoRequest(ctx, accountId)
+	if err != nil {
+		return getAccountInfoResponseData{}, fmt.Errorf("failed to create 'getAccountInfo' request: %w", err)
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return getAccountInfoResponseData{}, fmt.Errorf("failed to send 'getAccountInfo' request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var getAccountInfoResp getAccountInfoResponseData
+	err = json.NewDecoder(resp.Body).Decode(&getAccountInfoResp)
+	if err != nil {
+		return getAccountInfoResponseData{}, fmt.Errorf("failed to unmarshal 'getAccountInfo' response: %w", err)
+	}
+	return getAccountInfoResp, nil
+}
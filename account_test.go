@@ -0,0 +1,45 @@
+package gofile_test
+
+import (
+	"context"
+	"testing"
+
+	gofile "github.com/yaGatito/gofile-client"
+)
+
+func TestGetAccount(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	folderId := createTestFolder(t, client, srv.RootFolderId(srv.Token()), "docs")
+	uploadTestFile(t, client, srv, "a.txt", []byte("abc"))
+	uploadTestFileTo(t, client, folderId, "b.txt", []byte("defgh"))
+
+	account, err := client.GetAccount(context.Background())
+	if err != nil {
+		t.Fatalf("GetAccount: %v", err)
+	}
+	if account.Id != srv.AccountId(srv.Token()) || account.RootFolderId != srv.RootFolderId(srv.Token()) {
+		t.Errorf("got account %q with root %q, want the server's account", account.Id, account.RootFolderId)
+	}
+	if account.Email != "test@example.com" || account.Tier != gofile.TierStandard || account.IsPremium() {
+		t.Errorf("got email %q, tier %q, want test@example.com on the standard tier", account.Email, account.Tier)
+	}
+	if account.FolderCount != 1 || account.FileCount != 2 || account.Storage != 8 {
+		t.Errorf("got %d folders, %d files, %d bytes, want 1, 2 and 8",
+			account.FolderCount, account.FileCount, account.Storage)
+	}
+}
+
+func TestGetAccountReflectsTierChanges(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	srv.SetTier(srv.Token(), gofile.TierPremium)
+
+	account, err := client.GetAccount(context.Background())
+	if err != nil {
+		t.Fatalf("GetAccount: %v", err)
+	}
+	if account.Tier != gofile.TierPremium || !account.IsPremium() {
+		t.Errorf("got tier %q, want %q", account.Tier, gofile.TierPremium)
+	}
+}
@@ -0,0 +1,87 @@
+package gofile
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentAttribute is a single attribute update accepted by UpdateContent.
+//
+// Values are created with the constructor matching the attribute,
+// e.g. NameAttribute or ExpiryAttribute.
+type ContentAttribute struct {
+	name  string
+	value any
+	err   error
+}
+
+// NameAttribute renames a file or folder.
+func NameAttribute(name string) ContentAttribute {
+	if name == "" {
+		return ContentAttribute{name: "name", err: fmt.Errorf("empty name")}
+	}
+	return ContentAttribute{name: "name", value: name}
+}
+
+// DescriptionAttribute sets the description of a folder.
+func DescriptionAttribute(description string) ContentAttribute {
+	return ContentAttribute{name: "description", value: description}
+}
+
+// TagsAttribute replaces the tags of a folder.
+func TagsAttribute(tags ...string) ContentAttribute {
+	for _, tag := range tags {
+		if tag == "" || strings.Contains(tag, ",") {
+			return ContentAttribute{name: "tags", err: fmt.Errorf("invalid tag %q", tag)}
+		}
+	}
+	return ContentAttribute{name: "tags", value: strings.Join(tags, ",")}
+}
+
+// PublicAttribute makes a folder public or private.
+func PublicAttribute(public bool) ContentAttribute {
+	return ContentAttribute{name: "public", value: strconv.FormatBool(public)}
+}
+
+// ExpiryAttribute sets the moment after which a folder is no longer accessible.
+func ExpiryAttribute(expiry time.Time) ContentAttribute {
+	if expiry.IsZero() {
+		return ContentAttribute{name: "expiry", err: fmt.Errorf("zero expiry time")}
+	}
+	return ContentAttribute{name: "expiry", value: expiry.Unix()}
+}
+
+// PasswordAttribute protects a folder with a password.
+func PasswordAttribute(password string) ContentAttribute {
+	if password == "" {
+		return ContentAttribute{name: "password", err: fmt.Errorf("empty password")}
+	}
+	return ContentAttribute{name: "password", value: password}
+}
+
+// Name returns the API name of the attribute.
+func (a ContentAttribute) Name() string {
+	return a.name
+}
+
+// String returns the attribute name and value, hiding password values.
+func (a ContentAttribute) String() string {
+	if a.name == "password" {
+		return "password: ***"
+	}
+	return fmt.Sprintf("%s: %v", a.name, a.value)
+}
+
+// validate reports whether the attribute was built by one of the constructors
+// with a valid value.
+func (a ContentAttribute) validate() error {
+	if a.name == "" {
+		return fmt.Errorf("attribute is not specified")
+	}
+	if a.err != nil {
+		return fmt.Errorf("invalid '%s' attribute: %w", a.name, a.err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package gofile_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gofile "github.com/yaGatito/gofile-client"
+)
+
+func TestContentAttributes(t *testing.T) {
+	expiry := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		attr       gofile.ContentAttribute
+		wantName   string
+		wantString string
+	}{
+		{gofile.NameAttribute("report.pdf"), "name", "name: report.pdf"},
+		{gofile.DescriptionAttribute("monthly reports"), "description", "description: monthly reports"},
+		{gofile.DescriptionAttribute(""), "description", "description: "},
+		{gofile.TagsAttribute("work", "2030"), "tags", "tags: work,2030"},
+		{gofile.TagsAttribute(), "tags", "tags: "},
+		{gofile.PublicAttribute(true), "public", "public: true"},
+		{gofile.PublicAttribute(false), "public", "public: false"},
+		{gofile.ExpiryAttribute(expiry), "expiry", "expiry: 1893553445"},
+		{gofile.PasswordAttribute("secret"), "password", "password: ***"},
+	}
+	for _, tt := range tests {
+		if got := tt.attr.Name(); got != tt.wantName {
+			t.Errorf("Name() = %q, want %q", got, tt.wantName)
+		}
+		if got := tt.attr.String(); got != tt.wantString {
+			t.Errorf("String() = %q, want %q", got, tt.wantString)
+		}
+	}
+}
+
+func TestUpdateContentRejectsInvalidAttributes(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	folderId := createTestFolder(t, client, srv.RootFolderId(srv.Token()), "docs")
+
+	tests := []struct {
+		name string
+		attr gofile.ContentAttribute
+	}{
+		{"empty name", gofile.NameAttribute("")},
+		{"empty tag", gofile.TagsAttribute("work", "")},
+		{"tag with comma", gofile.TagsAttribute("a,b")},
+		{"zero expiry", gofile.ExpiryAttribute(time.Time{})},
+		{"empty password", gofile.PasswordAttribute("")},
+		{"zero value", gofile.ContentAttribute{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := client.UpdateContent(context.Background(), folderId, tt.attr); err == nil {
+				t.Errorf("UpdateContent(%v) succeeded, want an error", tt.attr)
+			}
+		})
+	}
+}
+
+func TestUpdateContent(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	ctx := context.Background()
+	folderId := createTestFolder(t, client, srv.RootFolderId(srv.Token()), "docs")
+	fileId := uploadTestFileTo(t, client, folderId, "draft.txt", []byte("draft")).Data.Id
+
+	attrs := []gofile.ContentAttribute{
+		gofile.NameAttribute("reports"),
+		gofile.DescriptionAttribute("monthly reports"),
+		gofile.TagsAttribute("work", "2030"),
+		gofile.PublicAttribute(true),
+		gofile.ExpiryAttribute(time.Now().Add(time.Hour)),
+		gofile.PasswordAttribute("secret"),
+	}
+	for _, attr := range attrs {
+		if err := client.UpdateContent(ctx, folderId, attr); err != nil {
+			t.Fatalf("UpdateContent(%v): %v", attr, err)
+		}
+	}
+	if err := client.UpdateContent(ctx, fileId, gofile.NameAttribute("final.txt")); err != nil {
+		t.Fatalf("renaming file: %v", err)
+	}
+
+	contents, err := client.GetFolderContents(ctx, folderId)
+	if err != nil {
+		t.Fatalf("GetFolderContents: %v", err)
+	}
+	if contents.Data.Name != "reports" || !contents.Data.Public {
+		t.Errorf("got folder %q, public %t, want reports, public", contents.Data.Name, contents.Data.Public)
+	}
+	if files := contents.Files(); len(files) != 1 || files[0].Name != "final.txt" {
+		t.Errorf("got files %+v, want final.txt", files)
+	}
+}
+
+func TestUpdateContentFolderAttributeOnFile(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	fileId := uploadTestFile(t, client, srv, "a.txt", []byte("a")).Data.Id
+
+	err := client.UpdateContent(context.Background(), fileId, gofile.PublicAttribute(true))
+	if err == nil {
+		t.Error("UpdateContent set a folder attribute on a file")
+	}
+}
@@ -0,0 +1,94 @@
+package gofile
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// bandwidthChunkSize bounds the bytes moved by a single throttled read,
+// so that concurrent transfers take turns on a shared limit.
+const bandwidthChunkSize = 32 << 10
+
+type bandwidthContextKey struct{}
+
+// ContextWithBandwidthLimit returns a context capping every transfer made
+// with it to bytesPerSecond, on top of the client-wide limits.
+// A value <= 0 removes the per-transfer cap.
+//
+// The cap is fixed for the lifetime of the transfers: only the client-wide
+// caps can be changed at runtime, with GofileClient.SetBandwidthLimit.
+func ContextWithBandwidthLimit(ctx context.Context, bytesPerSecond int64) context.Context {
+	return context.WithValue(ctx, bandwidthContextKey{}, bytesPerSecond)
+}
+
+// SetBandwidthLimit changes the client-wide bandwidth cap of OperationUpload
+// or OperationDownload at runtime. The cap is shared by all concurrent
+// transfers of the operation, and transfers already waiting on it switch
+// to the new cap immediately. A value <= 0 removes the cap.
+func (c *GofileClient) SetBandwidthLimit(operation string, bytesPerSecond int64) error {
+	bucket, ok := c.bandwidth[operation]
+	if !ok {
+		return fmt.Errorf("unknown transfer operation %q", operation)
+	}
+	bucket.setRate(float64(bytesPerSecond), bandwidthChunkSize)
+	return nil
+}
+
+// newBandwidthLimits returns unlimited client-wide buckets for every operation.
+func newBandwidthLimits() map[string]*tokenBucket {
+	return map[string]*tokenBucket{
+		OperationUpload:   newTokenBucket(0, bandwidthChunkSize),
+		OperationDownload: newTokenBucket(0, bandwidthChunkSize),
+	}
+}
+
+// throttle applies the client-wide and per-transfer bandwidth caps of a transfer.
+type throttle struct {
+	buckets []*tokenBucket
+}
+
+// newThrottle returns the throttle of a transfer of the given operation made with ctx.
+func (c *GofileClient) newThrottle(ctx context.Context, operation string) *throttle {
+	t := &throttle{buckets: []*tokenBucket{c.bandwidth[operation]}}
+	if limit, ok := ctx.Value(bandwidthContextKey{}).(int64); ok && limit > 0 {
+		t.buckets = append(t.buckets, newTokenBucket(float64(limit), bandwidthChunkSize))
+	}
+	return t
+}
+
+// wait blocks until n bytes may be transferred under every cap or ctx is done.
+func (t *throttle) wait(ctx context.Context, n int) error {
+	for _, bucket := range t.buckets {
+		if err := bucket.wait(ctx, float64(n)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reader wraps r so that reads are throttled.
+func (t *throttle) reader(ctx context.Context, r io.ReadCloser) io.ReadCloser {
+	return &throttledReader{ReadCloser: r, ctx: ctx, throttle: t}
+}
+
+// throttledReader reads in chunks of at most bandwidthChunkSize bytes
+// and waits on the throttle after every read.
+type throttledReader struct {
+	io.ReadCloser
+	ctx      context.Context
+	throttle *throttle
+}
+
+func (r *throttledReader) Read(p []byte) (int, error) {
+	if len(p) > bandwidthChunkSize {
+		p = p[:bandwidthChunkSize]
+	}
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		if waitErr := r.throttle.wait(r.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
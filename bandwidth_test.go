@@ -0,0 +1,82 @@
+package gofile_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	gofile "github.com/yaGatito/gofile-client"
+	"github.com/yaGatito/gofile-client/gofiletest"
+)
+
+// The first 32 KiB of a throttled transfer are sent at once, as the burst
+// of the limiter; the rest is spread over time.
+const (
+	throttledSize  = 160 << 10
+	throttledLimit = 256 << 10 // the 128 KiB after the burst take 500ms
+)
+
+func TestBandwidthLimitThrottlesDownloads(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv, gofile.WithBandwidthLimit(gofile.OperationDownload, throttledLimit))
+	uploaded := uploadTestFile(t, client, srv, "data.bin", testData(throttledSize))
+
+	start := time.Now()
+	downloadAll(t, context.Background(), client, uploaded.Data.Id, "data.bin")
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("download took %v, want about 500ms under the cap", elapsed)
+	}
+}
+
+func TestContextWithBandwidthLimitThrottlesDownloads(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	uploaded := uploadTestFile(t, client, srv, "data.bin", testData(throttledSize))
+
+	ctx := gofile.ContextWithBandwidthLimit(context.Background(), throttledLimit)
+	start := time.Now()
+	downloadAll(t, ctx, client, uploaded.Data.Id, "data.bin")
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("download took %v, want about 500ms under the cap", elapsed)
+	}
+}
+
+func TestSetBandwidthLimitAppliesToTransfersInProgress(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv, gofile.WithBandwidthLimit(gofile.OperationDownload, 1<<10))
+	uploaded := uploadTestFile(t, client, srv, "data.bin", testData(throttledSize))
+
+	done := make(chan error, 1)
+	go func() {
+		body, err := client.DownloadFile(context.Background(), gofiletest.StoreServer, uploaded.Data.Id, "data.bin")
+		if err == nil {
+			_, err = io.Copy(io.Discard, body)
+			body.Close()
+		}
+		done <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if err := client.SetBandwidthLimit(gofile.OperationDownload, 0); err != nil {
+		t.Fatalf("SetBandwidthLimit: %v", err)
+	}
+
+	// At 1 KiB/s the download would take more than two minutes.
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("downloading: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("download kept the cap it started with")
+	}
+}
+
+func TestSetBandwidthLimitRejectsUnknownOperation(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+
+	if err := client.SetBandwidthLimit("sync", 1<<20); err == nil {
+		t.Error("SetBandwidthLimit accepted an unknown operation")
+	}
+}
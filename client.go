@@ -2,12 +2,11 @@ package gofile
 
 import (
 	"context"
-	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
-	"os"
 	"sync"
+	"time"
 )
 
 // Gofile defines the public contract for interacting with the GoFile API.
@@ -16,8 +15,30 @@ import (
 type Gofile interface {
 	GetFileInfo(ctx context.Context, websiteToken, fileId string) (GetFileInfoResponseBody, error)
 	DownloadFile(ctx context.Context, server, fileId, fileName string) (io.ReadCloser, error)
+	DownloadFileFromServers(ctx context.Context, serverSelected string, servers []string, fileId, fileName string) (io.ReadCloser, string, error)
+	DownloadFileVerified(ctx context.Context, fileInfo GetFileInfoResponseBody) (io.ReadCloser, error)
+	DownloadRange(ctx context.Context, server, fileId, fileName string, offset, length int64) (io.ReadCloser, error)
+	DownloadToFile(ctx context.Context, fileInfo GetFileInfoResponseBody, path string) (int64, error)
+	DownloadParallel(ctx context.Context, fileId string, w io.WriterAt, opts ParallelDownloadOptions) (int64, error)
 	CreateFolder(ctx context.Context, parentFolderId, newFolderName string) (CreateFolderResponseBody, error)
+	GetFolderContents(ctx context.Context, folderId string) (GetFolderContentsResponseBody, error)
 	UploadFile(ctx context.Context, folderId, fileName string, fileReader io.ReadCloser) (UploadFileResponseBody, error)
+	DeleteContents(ctx context.Context, ids ...string) (ContentsOperationResponseBody, error)
+	UpdateContent(ctx context.Context, contentId string, attr ContentAttribute) error
+	CopyContents(ctx context.Context, destFolderId string, ids ...string) (ContentsOperationResponseBody, error)
+	MoveContents(ctx context.Context, destFolderId string, ids ...string) (ContentsOperationResponseBody, error)
+	ImportContents(ctx context.Context, ids ...string) (ContentsOperationResponseBody, error)
+	ImportContentsTo(ctx context.Context, destFolderId string, ids ...string) (ContentsOperationResponseBody, error)
+	CreateDirectLink(ctx context.Context, contentId string, opts DirectLinkOptions) (DirectLink, error)
+	UpdateDirectLink(ctx context.Context, contentId, directLinkId string, opts DirectLinkOptions) (DirectLink, error)
+	DeleteDirectLink(ctx context.Context, contentId, directLinkId string) error
+	ListDirectLinks(ctx context.Context, contentId string) ([]DirectLink, error)
+	GetAccount(ctx context.Context) (Account, error)
+	Search(ctx context.Context, folderId string, query SearchQuery) ([]SearchResult, error)
+	GetServers(ctx context.Context, zone string) ([]Server, error)
+	RateLimiterState() map[EndpointClass]RateLimiterState
+	SetRateLimit(class EndpointClass, limit RateLimit)
+	SetBandwidthLimit(operation string, bytesPerSecond int64) error
 }
 
 var _ Gofile = &GofileClient{}
@@ -28,9 +49,39 @@ var _ Gofile = &GofileClient{}
 // A client instance caches account and root folder identifiers internally
 // and may be used concurrently by multiple goroutines.
 type GofileClient struct {
-	apiKey string
-	client *http.Client
-	logger *log.Logger
+	client      *http.Client
+	logger      *slog.Logger
+	userAgent   string
+	retryPolicy RetryPolicy
+	retryMu     sync.RWMutex
+	limiter     *rateLimiter
+
+	// doer sends every request: the HTTP client wrapped by the middleware chain.
+	middleware []Middleware
+	doer       Doer
+	metrics    MetricsCollector
+
+	// secrets are the token values redacted from logs.
+	secrets *secrets
+
+	progress         ProgressFunc
+	progressInterval time.Duration
+	bandwidth        map[string]*tokenBucket
+
+	deleteCorruptUploads bool
+
+	apiBaseURL          string
+	uploadURL           string
+	downloadURLTemplate string
+	uploadServers       *uploadServerSelector
+
+	// apiKey is the bearer token sent with every request. In guest mode it is
+	// empty until the first upload returns a guest token.
+	apiKey        string
+	guest         bool
+	guestFolderId string
+	tokenMu       sync.RWMutex
+	guestUploadMu sync.Mutex
 
 	accountIdCached string
 	accountIdOnce   sync.Once
@@ -44,25 +95,77 @@ type GofileClient struct {
 // New creates a new GofileClient using the provided API key.
 //
 // If httpClient is nil, http.DefaultClient is used.
-// If logger is nil, a default logger writing to stdout is created.
+// If logger is nil, a default logger writing text to stdout at the Info level is created.
+// Tokens are redacted from every record, whichever logger is used.
 //
 // The function returns nil if apiKey is empty.
-func New(apiKey string, client *http.Client, logger *log.Logger) (Gofile, error) {
-	if apiKey == "" {
-		return nil, fmt.Errorf("empty apiKey")
+// Use NewWithOptions to configure endpoints and other settings.
+func New(apiKey string, client *http.Client, logger *slog.Logger) (Gofile, error) {
+	c, err := NewWithOptions(apiKey, WithHTTPClient(client), WithLogger(logger))
+	if err != nil {
+		return nil, err
 	}
-	if client == nil {
-		client = &http.Client{}
-	}
-	if logger == nil {
-		logger = log.New(os.Stdout, "[GOFILE-CLIENT] ", log.Ldate|log.Ltime|log.Lmicroseconds|log.LUTC)
+	return c, nil
+}
+
+// NewGuest creates a new GofileClient that works without an API key.
+//
+// The first upload creates an anonymous guest account. The guest token and
+// guest root folder returned by that upload are captured by the client and
+// reused, so later uploads to "root" land in the same folder.
+//
+// If httpClient is nil, http.DefaultClient is used.
+// If logger is nil, a default logger writing text to stdout at the Info level is created.
+func NewGuest(client *http.Client, logger *slog.Logger) (Gofile, error) {
+	c, err := NewGuestWithOptions(WithHTTPClient(client), WithLogger(logger))
+	if err != nil {
+		return nil, err
 	}
+	return c, nil
+}
 
+// newClient creates a GofileClient with default settings.
+func newClient(apiKey string) *GofileClient {
 	c := &GofileClient{
-		apiKey: apiKey,
-		client: client,
-		logger: logger,
+		apiKey:              apiKey,
+		client:              &http.Client{},
+		logger:              defaultLogger(),
+		secrets:             &secrets{},
+		metrics:             noopMetrics{},
+		retryPolicy:         DefaultRetryPolicy(),
+		limiter:             newRateLimiter(DefaultRateLimits()),
+		progressInterval:    defaultProgressInterval,
+		bandwidth:           newBandwidthLimits(),
+		apiBaseURL:          defaultAPIBaseURL,
+		uploadURL:           defaultUploadURL,
+		downloadURLTemplate: defaultDownloadURLTemplate,
 	}
+	c.secrets.add(apiKey)
+	return c
+}
 
-	return c, nil
+// token returns the bearer token currently used by the client.
+func (c *GofileClient) token() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.apiKey
+}
+
+// guestSession returns the captured guest token and guest root folder ID.
+// Both are empty until the first guest upload completes.
+func (c *GofileClient) guestSession() (token, folderId string) {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.apiKey, c.guestFolderId
+}
+
+// setGuestSession stores the guest token and guest root folder ID
+// returned by an upload.
+func (c *GofileClient) setGuestSession(token, folderId string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	c.secrets.remove(c.apiKey)
+	c.secrets.add(token)
+	c.apiKey = token
+	c.guestFolderId = folderId
 }
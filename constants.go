@@ -3,10 +3,25 @@ package gofile
 // RootFolder used to specify the root folder ID that is behind the scene.
 const RootFolder = "root"
 
+// Default endpoints, overridable with WithAPIBaseURL, WithUploadURL and WithDownloadURLTemplate.
 const (
-	postFolderEndpoint = "https://api.gofile.io/contents/createFolder"
-	contentsBaseURL    = "https://api.gofile.io/contents/"
-	accountsBaseURL    = "https://api.gofile.io/accounts/"
-	getFileEndpoint    = "https://%s.gofile.io/download/web/%s/%s"
-	postFileEndpoint   = "https://upload.gofile.io/uploadfile"
+	defaultAPIBaseURL          = "https://api.gofile.io"
+	defaultUploadURL           = "https://upload.gofile.io/uploadfile"
+	defaultDownloadURLTemplate = "https://%s.gofile.io/download/web/%s/%s"
 )
+
+// Content types reported by the GoFile API.
+const (
+	ContentTypeFile   = "file"
+	ContentTypeFolder = "folder"
+)
+
+// Account tiers reported by the GoFile API.
+const (
+	TierGuest    = "guest"
+	TierStandard = "standard"
+	TierPremium  = "premium"
+)
+
+// statusOk is the value of the "status" field of a successful API response.
+const statusOk = "ok"
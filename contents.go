@@ -0,0 +1,296 @@
+package gofile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DeleteContents deletes the specified files and folders.
+//
+// The returned body holds a result for every requested ID, so a partial
+// failure can be inspected with ContentsOperationResponseBody.Failed.
+func (c *GofileClient) DeleteContents(ctx context.Context, ids ...string) (_ ContentsOperationResponseBody, err error) {
+	defer c.observeOperation("DeleteContents", time.Now(), &err)
+	contentsId, err := joinContentsIds(ids)
+	if err != nil {
+		return ContentsOperationResponseBody{}, err
+	}
+
+	req, err := c.createDeleteContentsRequest(ctx, contentsId)
+	if err != nil {
+		return ContentsOperationResponseBody{}, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return ContentsOperationResponseBody{}, err
+	}
+	defer resp.Body.Close()
+
+	var deleteContentsResponseBody ContentsOperationResponseBody
+	err = json.NewDecoder(resp.Body).Decode(&deleteContentsResponseBody)
+	if err != nil {
+		return ContentsOperationResponseBody{}, err
+	}
+	return deleteContentsResponseBody, nil
+}
+
+// createDeleteContentsRequest builds an HTTP DELETE request for deleting
+// the comma-separated list of content IDs.
+func (c *GofileClient) createDeleteContentsRequest(ctx context.Context, contentsId string) (*http.Request, error) {
+	jsonBody, err := json.Marshal(contentsRequestBody{ContentsId: contentsId})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling 'deleteContents' body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.contentsEndpoint(), bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("creating 'deleteContents' request: %w", err)
+	}
+	req.Header.Set(contentTypeHeader, applicationJsonContentType)
+
+	return req, nil
+}
+
+// UpdateContent updates a single attribute of the specified file or folder.
+//
+// Name applies to files and folders; the remaining attributes apply to folders only.
+func (c *GofileClient) UpdateContent(ctx context.Context, contentId string, attr ContentAttribute) (err error) {
+	defer c.observeOperation("UpdateContent", time.Now(), &err)
+	if contentId == "" {
+		return fmt.Errorf("contentId is not specified")
+	}
+	if err := attr.validate(); err != nil {
+		return err
+	}
+
+	req, err := c.createPutUpdateContentRequest(ctx, contentId, attr)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// createPutUpdateContentRequest builds an HTTP PUT request for updating
+// an attribute of the specified content ID.
+func (c *GofileClient) createPutUpdateContentRequest(ctx context.Context, contentId string, attr ContentAttribute) (*http.Request, error) {
+	jsonBody, err := json.Marshal(updateContentRequestBody{
+		Attribute:      attr.name,
+		AttributeValue: attr.value,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling 'updateContent' body: %w", err)
+	}
+
+	url := fmt.Sprintf("%s%s/update", c.contentsBaseURL(), url.PathEscape(contentId))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("creating 'updateContent' request: %w", err)
+	}
+	req.Header.Set(contentTypeHeader, applicationJsonContentType)
+
+	return req, nil
+}
+
+// CopyContents copies the specified files and folders into the destination folder.
+//
+// The destFolderId may be a concrete folder identifier or the special value "root".
+// When "root" is provided, the client's root folder ID is resolved automatically.
+func (c *GofileClient) CopyContents(ctx context.Context, destFolderId string, ids ...string) (_ ContentsOperationResponseBody, err error) {
+	defer c.observeOperation("CopyContents", time.Now(), &err)
+	return c.transferContents(ctx, http.MethodPost, "copy", destFolderId, ids)
+}
+
+// MoveContents moves the specified files and folders into the destination folder.
+//
+// The destFolderId may be a concrete folder identifier or the special value "root".
+// When "root" is provided, the client's root folder ID is resolved automatically.
+func (c *GofileClient) MoveContents(ctx context.Context, destFolderId string, ids ...string) (_ ContentsOperationResponseBody, err error) {
+	defer c.observeOperation("MoveContents", time.Now(), &err)
+	return c.transferContents(ctx, http.MethodPut, "move", destFolderId, ids)
+}
+
+// transferContents sends a copy or move request for the given contents
+// into the destination folder.
+func (c *GofileClient) transferContents(
+	ctx context.Context,
+	method, operation, destFolderId string,
+	ids []string,
+) (ContentsOperationResponseBody, error) {
+
+	if destFolderId == "" {
+		return ContentsOperationResponseBody{}, fmt.Errorf("destFolderId is not specified")
+	}
+	contentsId, err := joinContentsIds(ids)
+	if err != nil {
+		return ContentsOperationResponseBody{}, err
+	}
+	destFolderId, err = c.resolveFolderId(ctx, destFolderId)
+	if err != nil {
+		return ContentsOperationResponseBody{}, err
+	}
+
+	req, err := c.createTransferContentsRequest(ctx, method, operation, destFolderId, contentsId)
+	if err != nil {
+		return ContentsOperationResponseBody{}, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return ContentsOperationResponseBody{}, err
+	}
+	defer resp.Body.Close()
+
+	var transferContentsResponseBody ContentsOperationResponseBody
+	err = json.NewDecoder(resp.Body).Decode(&transferContentsResponseBody)
+	if err != nil {
+		return ContentsOperationResponseBody{}, err
+	}
+	return transferContentsResponseBody, nil
+}
+
+// createTransferContentsRequest builds an HTTP request for the copy or move
+// endpoint with the comma-separated list of content IDs and the destination folder.
+func (c *GofileClient) createTransferContentsRequest(
+	ctx context.Context,
+	method, operation, destFolderId, contentsId string,
+) (*http.Request, error) {
+
+	jsonBody, err := json.Marshal(contentsRequestBody{
+		ContentsId: contentsId,
+		FolderId:   destFolderId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling '%s' body: %w", operation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.contentsBaseURL()+operation, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("creating '%s' request: %w", operation, err)
+	}
+	req.Header.Set(contentTypeHeader, applicationJsonContentType)
+
+	return req, nil
+}
+
+// ImportContents imports public contents shared by other accounts
+// into the root folder of the client's account.
+//
+// The IDs of the imported copies are available through
+// ContentsOperationResponseBody.NewIds.
+func (c *GofileClient) ImportContents(ctx context.Context, ids ...string) (_ ContentsOperationResponseBody, err error) {
+	defer c.observeOperation("ImportContents", time.Now(), &err)
+	contentsId, err := joinContentsIds(ids)
+	if err != nil {
+		return ContentsOperationResponseBody{}, err
+	}
+
+	req, err := c.createPostImportContentsRequest(ctx, contentsId)
+	if err != nil {
+		return ContentsOperationResponseBody{}, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return ContentsOperationResponseBody{}, err
+	}
+	defer resp.Body.Close()
+
+	var importContentsResponseBody ContentsOperationResponseBody
+	err = json.NewDecoder(resp.Body).Decode(&importContentsResponseBody)
+	if err != nil {
+		return ContentsOperationResponseBody{}, err
+	}
+	return importContentsResponseBody, nil
+}
+
+// ImportContentsTo imports public contents shared by other accounts
+// and moves the imported copies into the destination folder.
+//
+// The destFolderId may be a concrete folder identifier or the special value "root".
+// If moving fails, the import result is still returned along with the error,
+// and the imported copies remain in the root folder.
+func (c *GofileClient) ImportContentsTo(ctx context.Context, destFolderId string, ids ...string) (_ ContentsOperationResponseBody, err error) {
+	defer c.observeOperation("ImportContentsTo", time.Now(), &err)
+	if destFolderId == "" {
+		return ContentsOperationResponseBody{}, fmt.Errorf("destFolderId is not specified")
+	}
+	destFolderId, err = c.resolveFolderId(ctx, destFolderId)
+	if err != nil {
+		return ContentsOperationResponseBody{}, err
+	}
+	rootFolderId, err := c.resolveFolderId(ctx, rootFolderIdPlaceholderConst)
+	if err != nil {
+		return ContentsOperationResponseBody{}, err
+	}
+
+	result, err := c.ImportContents(ctx, ids...)
+	if err != nil {
+		return ContentsOperationResponseBody{}, err
+	}
+	if destFolderId == rootFolderId {
+		return result, nil
+	}
+
+	newIds := result.NewIds()
+	importedIds := make([]string, 0, len(newIds))
+	for _, newId := range newIds {
+		importedIds = append(importedIds, newId)
+	}
+	sort.Strings(importedIds)
+	if len(importedIds) == 0 {
+		return result, nil
+	}
+	moved, err := c.MoveContents(ctx, destFolderId, importedIds...)
+	if err != nil {
+		return result, fmt.Errorf("moving imported contents: %w", err)
+	}
+	if failed := moved.Failed(); len(failed) > 0 {
+		return result, fmt.Errorf("moving imported contents %v failed", failed)
+	}
+	return result, nil
+}
+
+// createPostImportContentsRequest builds an HTTP POST request for importing
+// the comma-separated list of content IDs.
+func (c *GofileClient) createPostImportContentsRequest(ctx context.Context, contentsId string) (*http.Request, error) {
+	jsonBody, err := json.Marshal(contentsRequestBody{ContentsId: contentsId})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling 'importContents' body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.contentsBaseURL()+"import", bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("creating 'importContents' request: %w", err)
+	}
+	req.Header.Set(contentTypeHeader, applicationJsonContentType)
+
+	return req, nil
+}
+
+// joinContentsIds validates the content IDs and joins them into
+// the comma-separated form expected by the bulk contents endpoints.
+func joinContentsIds(ids []string) (string, error) {
+	if len(ids) == 0 {
+		return "", fmt.Errorf("no content ids specified")
+	}
+	for _, id := range ids {
+		if id == "" {
+			return "", fmt.Errorf("empty content id provided")
+		}
+		if strings.Contains(id, ",") {
+			return "", fmt.Errorf("invalid content id %q", id)
+		}
+	}
+	return strings.Join(ids, ","), nil
+}
@@ -0,0 +1,215 @@
+package gofile_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	gofile "github.com/yaGatito/gofile-client"
+)
+
+func TestDeleteContentsReportsPartialFailures(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	first := uploadTestFile(t, client, srv, "first.txt", []byte("first")).Data.Id
+	second := uploadTestFile(t, client, srv, "second.txt", []byte("second")).Data.Id
+
+	result, err := client.DeleteContents(context.Background(), first, "missing", second)
+	if err != nil {
+		t.Fatalf("DeleteContents: %v", err)
+	}
+
+	wantSucceeded := []string{first, second}
+	sort.Strings(wantSucceeded)
+	if got := result.Succeeded(); !reflect.DeepEqual(got, wantSucceeded) {
+		t.Errorf("Succeeded() = %v, want %v", got, wantSucceeded)
+	}
+	if got := result.Failed(); !reflect.DeepEqual(got, []string{"missing"}) {
+		t.Errorf("Failed() = %v, want [missing]", got)
+	}
+	if _, ok := srv.FileData(first); ok {
+		t.Error("first file still exists after DeleteContents")
+	}
+	if _, ok := srv.FileData(second); ok {
+		t.Error("second file still exists after DeleteContents")
+	}
+}
+
+func TestDeleteContentsRejectsInvalidIds(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+
+	for _, ids := range [][]string{nil, {""}, {"a,b"}} {
+		if _, err := client.DeleteContents(context.Background(), ids...); err == nil {
+			t.Errorf("DeleteContents(%q) succeeded, want an error", ids)
+		}
+	}
+}
+
+func TestTransferContentsRequests(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		transfer func(client *gofile.GofileClient, destFolderId string, ids ...string) (gofile.ContentsOperationResponseBody, error)
+	}{
+		{
+			name:   "copy",
+			method: http.MethodPost,
+			path:   "/contents/copy",
+			transfer: func(client *gofile.GofileClient, destFolderId string, ids ...string) (gofile.ContentsOperationResponseBody, error) {
+				return client.CopyContents(context.Background(), destFolderId, ids...)
+			},
+		},
+		{
+			name:   "move",
+			method: http.MethodPut,
+			path:   "/contents/move",
+			transfer: func(client *gofile.GofileClient, destFolderId string, ids ...string) (gofile.ContentsOperationResponseBody, error) {
+				return client.MoveContents(context.Background(), destFolderId, ids...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(t)
+			recorder := &requestRecorder{}
+			recorder.stub(tt.method, tt.path, `{"status":"ok","data":{`+
+				`"a":{"status":"ok","data":{"id":"a2"}},`+
+				`"b":{"status":"error-notFound","data":{}}}}`)
+			client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+
+			result, err := tt.transfer(client, "root", "a", "b")
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+
+			req, ok := recorder.find(tt.method, tt.path)
+			if !ok {
+				t.Fatalf("no %s %s request was sent", tt.method, tt.path)
+			}
+			var body map[string]string
+			if err := json.Unmarshal([]byte(req.body), &body); err != nil {
+				t.Fatalf("decoding request body %q: %v", req.body, err)
+			}
+			want := map[string]string{"contentsId": "a,b", "folderId": srv.RootFolderId(srv.Token())}
+			if !reflect.DeepEqual(body, want) {
+				t.Errorf("got request body %v, want %v", body, want)
+			}
+			if got := result.Succeeded(); !reflect.DeepEqual(got, []string{"a"}) {
+				t.Errorf("Succeeded() = %v, want [a]", got)
+			}
+			if got := result.Failed(); !reflect.DeepEqual(got, []string{"b"}) {
+				t.Errorf("Failed() = %v, want [b]", got)
+			}
+		})
+	}
+}
+
+func TestTransferContentsRejectsInvalidArguments(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+	ctx := context.Background()
+
+	if _, err := client.CopyContents(ctx, "", "a"); err == nil {
+		t.Error("CopyContents succeeded without a destination folder")
+	}
+	if _, err := client.MoveContents(ctx, "folder"); err == nil {
+		t.Error("MoveContents succeeded without content IDs")
+	}
+	if _, err := client.MoveContents(ctx, "folder", "a,b"); err == nil {
+		t.Error("MoveContents succeeded with an ID containing a comma")
+	}
+	if len(recorder.requests) != 0 {
+		t.Errorf("invalid arguments sent %d requests, want none", len(recorder.requests))
+	}
+}
+
+// importResponse is an import result where "a" and "b" were copied
+// as "b2" and "a2", and "c" was not found.
+const importResponse = `{"status":"ok","data":{` +
+	`"a":{"status":"ok","data":{"id":"b2"}},` +
+	`"b":{"status":"ok","data":{"id":"a2"}},` +
+	`"c":{"status":"error-notFound","data":{}}}}`
+
+func TestImportContents(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	recorder.stub(http.MethodPost, "/contents/import", importResponse)
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+
+	result, err := client.ImportContents(context.Background(), "a", "b", "c")
+	if err != nil {
+		t.Fatalf("ImportContents: %v", err)
+	}
+	if got, want := result.NewIds(), map[string]string{"a": "b2", "b": "a2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NewIds() = %v, want %v", got, want)
+	}
+	if got := result.Failed(); !reflect.DeepEqual(got, []string{"c"}) {
+		t.Errorf("Failed() = %v, want [c]", got)
+	}
+	req, _ := recorder.find(http.MethodPost, "/contents/import")
+	if req.body != `{"contentsId":"a,b,c"}` {
+		t.Errorf("got request body %s, want the joined content IDs", req.body)
+	}
+}
+
+func TestImportContentsTo(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	recorder.stub(http.MethodPost, "/contents/import", importResponse)
+	recorder.stub(http.MethodPut, "/contents/move", `{"status":"ok","data":{`+
+		`"a2":{"status":"ok","data":{}},"b2":{"status":"ok","data":{}}}}`)
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+
+	result, err := client.ImportContentsTo(context.Background(), "dest", "a", "b", "c")
+	if err != nil {
+		t.Fatalf("ImportContentsTo: %v", err)
+	}
+	if got := result.Succeeded(); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Succeeded() = %v, want the import result", got)
+	}
+	req, ok := recorder.find(http.MethodPut, "/contents/move")
+	if !ok {
+		t.Fatal("the imported contents were not moved")
+	}
+	if want := `{"contentsId":"a2,b2","folderId":"dest"}`; req.body != want {
+		t.Errorf("got move request body %s, want %s", req.body, want)
+	}
+}
+
+func TestImportContentsToRootDoesNotMove(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	recorder.stub(http.MethodPost, "/contents/import", importResponse)
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+
+	if _, err := client.ImportContentsTo(context.Background(), "root", "a", "b"); err != nil {
+		t.Fatalf("ImportContentsTo: %v", err)
+	}
+	if _, ok := recorder.find(http.MethodPut, "/contents/move"); ok {
+		t.Error("contents imported into the root folder were moved")
+	}
+}
+
+func TestImportContentsToReportsFailedMoves(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	recorder.stub(http.MethodPost, "/contents/import", importResponse)
+	recorder.stub(http.MethodPut, "/contents/move", `{"status":"ok","data":{`+
+		`"a2":{"status":"ok","data":{}},"b2":{"status":"error-notFound","data":{}}}}`)
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+
+	result, err := client.ImportContentsTo(context.Background(), "dest", "a", "b")
+	if err == nil || !strings.Contains(err.Error(), "b2") {
+		t.Errorf("got error %v, want the failed move of b2", err)
+	}
+	if len(result.NewIds()) != 2 {
+		t.Errorf("got import result %v, want it returned along with the error", result)
+	}
+}
@@ -0,0 +1,206 @@
+package gofile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DirectLinkOptions configures the restrictions of a direct link.
+//
+// Zero values leave the corresponding restriction unset on creation
+// and clear it on update.
+type DirectLinkOptions struct {
+	// ExpireTime is the moment after which the link stops working.
+	ExpireTime time.Time
+	// SourceIpsAllowed restricts downloads to the listed client IP addresses.
+	SourceIpsAllowed []string
+	// DomainsAllowed restricts downloads to requests referred by the listed domains.
+	DomainsAllowed []string
+	// Auth protects the link with HTTP basic authentication.
+	Auth []DirectLinkCredentials
+}
+
+// DirectLinkCredentials is a username and password pair accepted by a direct link.
+type DirectLinkCredentials struct {
+	Username string
+	Password string
+}
+
+// CreateDirectLink creates a direct download link for the specified file or folder.
+func (c *GofileClient) CreateDirectLink(ctx context.Context, contentId string, opts DirectLinkOptions) (_ DirectLink, err error) {
+	defer c.observeOperation("CreateDirectLink", time.Now(), &err)
+	if contentId == "" {
+		return DirectLink{}, fmt.Errorf("contentId is not specified")
+	}
+
+	requestBody, err := newDirectLinkRequestBody(opts)
+	if err != nil {
+		return DirectLink{}, err
+	}
+	url := fmt.Sprintf("%s%s/directlinks", c.contentsBaseURL(), url.PathEscape(contentId))
+	req, err := c.createDirectLinkRequest(ctx, http.MethodPost, url, requestBody)
+	if err != nil {
+		return DirectLink{}, err
+	}
+	return c.doDirectLinkRequest(req)
+}
+
+// UpdateDirectLink replaces the restrictions of an existing direct link.
+//
+// Every restriction is sent, so those left at their zero value in opts are removed.
+func (c *GofileClient) UpdateDirectLink(ctx context.Context, contentId, directLinkId string, opts DirectLinkOptions) (_ DirectLink, err error) {
+	defer c.observeOperation("UpdateDirectLink", time.Now(), &err)
+	if contentId == "" {
+		return DirectLink{}, fmt.Errorf("contentId is not specified")
+	}
+	if directLinkId == "" {
+		return DirectLink{}, fmt.Errorf("directLinkId is not specified")
+	}
+
+	requestBody, err := newDirectLinkRequestBody(opts)
+	if err != nil {
+		return DirectLink{}, err
+	}
+	url := fmt.Sprintf("%s%s/directlinks/%s", c.contentsBaseURL(), url.PathEscape(contentId), url.PathEscape(directLinkId))
+	req, err := c.createDirectLinkRequest(ctx, http.MethodPut, url, newUpdateDirectLinkRequestBody(requestBody))
+	if err != nil {
+		return DirectLink{}, err
+	}
+	return c.doDirectLinkRequest(req)
+}
+
+// DeleteDirectLink removes a direct link from the specified file or folder.
+func (c *GofileClient) DeleteDirectLink(ctx context.Context, contentId, directLinkId string) (err error) {
+	defer c.observeOperation("DeleteDirectLink", time.Now(), &err)
+	if contentId == "" {
+		return fmt.Errorf("contentId is not specified")
+	}
+	if directLinkId == "" {
+		return fmt.Errorf("directLinkId is not specified")
+	}
+
+	url := fmt.Sprintf("%s%s/directlinks/%s", c.contentsBaseURL(), url.PathEscape(contentId), url.PathEscape(directLinkId))
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return fmt.Errorf("creating 'deleteDirectLink' request: %w", err)
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// ListDirectLinks returns the direct links of the specified file or folder,
+// sorted by their IDs.
+func (c *GofileClient) ListDirectLinks(ctx context.Context, contentId string) (_ []DirectLink, err error) {
+	defer c.observeOperation("ListDirectLinks", time.Now(), &err)
+	if contentId == "" {
+		return nil, fmt.Errorf("contentId is not specified")
+	}
+
+	req, err := c.createGetFolderContentsRequest(ctx, contentId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var listDirectLinksResponseBody listDirectLinksResponseData
+	err = json.NewDecoder(resp.Body).Decode(&listDirectLinksResponseBody)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]DirectLink, 0, len(listDirectLinksResponseBody.Data.DirectLinks))
+	for id, link := range listDirectLinksResponseBody.Data.DirectLinks {
+		if link.Id == "" {
+			link.Id = id
+		}
+		links = append(links, link)
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].Id < links[j].Id
+	})
+	return links, nil
+}
+
+// doDirectLinkRequest sends a direct link create or update request
+// and decodes the returned link.
+func (c *GofileClient) doDirectLinkRequest(req *http.Request) (DirectLink, error) {
+	resp, err := c.do(req)
+	if err != nil {
+		return DirectLink{}, err
+	}
+	defer resp.Body.Close()
+
+	var directLinkResponseBody directLinkResponseData
+	err = json.NewDecoder(resp.Body).Decode(&directLinkResponseBody)
+	if err != nil {
+		return DirectLink{}, err
+	}
+	return directLinkResponseBody.Data, nil
+}
+
+// newDirectLinkRequestBody converts the direct link restrictions to their JSON form.
+func newDirectLinkRequestBody(opts DirectLinkOptions) (directLinkRequestBody, error) {
+	requestBody := directLinkRequestBody{
+		SourceIpsAllowed: opts.SourceIpsAllowed,
+		DomainsAllowed:   opts.DomainsAllowed,
+	}
+	if !opts.ExpireTime.IsZero() {
+		requestBody.ExpireTime = opts.ExpireTime.Unix()
+	}
+	for _, credentials := range opts.Auth {
+		if credentials.Username == "" || strings.Contains(credentials.Username, ":") {
+			return directLinkRequestBody{}, fmt.Errorf("invalid direct link username %q", credentials.Username)
+		}
+		requestBody.Auth = append(requestBody.Auth, credentials.Username+":"+credentials.Password)
+	}
+	return requestBody, nil
+}
+
+// newUpdateDirectLinkRequestBody returns the update form of requestBody,
+// where unset restrictions are sent as empty values so that they are cleared.
+func newUpdateDirectLinkRequestBody(requestBody directLinkRequestBody) updateDirectLinkRequestBody {
+	updateBody := updateDirectLinkRequestBody(requestBody)
+	if updateBody.SourceIpsAllowed == nil {
+		updateBody.SourceIpsAllowed = []string{}
+	}
+	if updateBody.DomainsAllowed == nil {
+		updateBody.DomainsAllowed = []string{}
+	}
+	if updateBody.Auth == nil {
+		updateBody.Auth = []string{}
+	}
+	return updateBody
+}
+
+// createDirectLinkRequest builds an HTTP request with a JSON body
+// describing the direct link restrictions.
+func (c *GofileClient) createDirectLinkRequest(ctx context.Context, method, url string, requestBody any) (*http.Request, error) {
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling 'directLink' body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("creating 'directLink' request: %w", err)
+	}
+	req.Header.Set(contentTypeHeader, applicationJsonContentType)
+
+	return req, nil
+}
@@ -0,0 +1,145 @@
+package gofile_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	gofile "github.com/yaGatito/gofile-client"
+)
+
+const directLinkResponse = `{"status":"ok","data":{"id":"l1","directLink":"https://store1.gofile.io/download/direct/l1/a.txt",` +
+	`"expireTime":1893553445,"sourceIpsAllowed":["10.0.0.1"],"domainsAllowed":[],"auth":["user:pass"],"isReqLink":false}}`
+
+// decodeBody decodes a recorded JSON request body into a generic map,
+// so that the presence of fields can be checked.
+func decodeBody(t *testing.T, req recordedRequest) map[string]any {
+	t.Helper()
+	var body map[string]any
+	if err := json.Unmarshal([]byte(req.body), &body); err != nil {
+		t.Fatalf("decoding request body %q: %v", req.body, err)
+	}
+	return body
+}
+
+func TestCreateDirectLink(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	recorder.stub(http.MethodPost, "/contents/f1/directlinks", directLinkResponse)
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+
+	link, err := client.CreateDirectLink(context.Background(), "f1", gofile.DirectLinkOptions{
+		ExpireTime:       time.Unix(1893553445, 0),
+		SourceIpsAllowed: []string{"10.0.0.1"},
+		Auth:             []gofile.DirectLinkCredentials{{Username: "user", Password: "pass"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateDirectLink: %v", err)
+	}
+	if link.Id != "l1" || !link.Expires().Equal(time.Unix(1893553445, 0)) {
+		t.Errorf("got link %v, want l1 expiring at 1893553445", link)
+	}
+
+	req, _ := recorder.find(http.MethodPost, "/contents/f1/directlinks")
+	want := map[string]any{
+		"expireTime":       float64(1893553445),
+		"sourceIpsAllowed": []any{"10.0.0.1"},
+		"auth":             []any{"user:pass"},
+	}
+	if body := decodeBody(t, req); !reflect.DeepEqual(body, want) {
+		t.Errorf("got request body %v, want %v", body, want)
+	}
+}
+
+func TestCreateDirectLinkOmitsUnsetRestrictions(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	recorder.stub(http.MethodPost, "/contents/f1/directlinks", directLinkResponse)
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+
+	if _, err := client.CreateDirectLink(context.Background(), "f1", gofile.DirectLinkOptions{}); err != nil {
+		t.Fatalf("CreateDirectLink: %v", err)
+	}
+
+	req, _ := recorder.find(http.MethodPost, "/contents/f1/directlinks")
+	if body := decodeBody(t, req); len(body) != 0 {
+		t.Errorf("got request body %v, want no restrictions", body)
+	}
+}
+
+func TestUpdateDirectLinkClearsUnsetRestrictions(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	recorder.stub(http.MethodPut, "/contents/f1/directlinks/l1", directLinkResponse)
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+
+	opts := gofile.DirectLinkOptions{DomainsAllowed: []string{"example.com"}}
+	if _, err := client.UpdateDirectLink(context.Background(), "f1", "l1", opts); err != nil {
+		t.Fatalf("UpdateDirectLink: %v", err)
+	}
+
+	req, _ := recorder.find(http.MethodPut, "/contents/f1/directlinks/l1")
+	want := map[string]any{
+		"expireTime":       float64(0),
+		"sourceIpsAllowed": []any{},
+		"domainsAllowed":   []any{"example.com"},
+		"auth":             []any{},
+	}
+	if body := decodeBody(t, req); !reflect.DeepEqual(body, want) {
+		t.Errorf("got request body %v, want %v", body, want)
+	}
+}
+
+func TestListDirectLinks(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	recorder.stub(http.MethodGet, "/contents/f1", `{"status":"ok","data":{"id":"f1","directLinks":{`+
+		`"l2":{"directLink":"https://example.com/l2"},`+
+		`"l1":{"directLink":"https://example.com/l1","auth":["user:pass"]}}}}`)
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+
+	links, err := client.ListDirectLinks(context.Background(), "f1")
+	if err != nil {
+		t.Fatalf("ListDirectLinks: %v", err)
+	}
+	if len(links) != 2 || links[0].Id != "l1" || links[1].Id != "l2" {
+		t.Fatalf("got links %v, want l1 and l2", links)
+	}
+	if len(links[0].Auth) != 1 {
+		t.Errorf("got link %v, want one credential", links[0])
+	}
+}
+
+func TestDeleteDirectLink(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	recorder.stub(http.MethodDelete, "/contents/f1/directlinks/l1", `{"status":"ok","data":{}}`)
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+
+	if err := client.DeleteDirectLink(context.Background(), "f1", "l1"); err != nil {
+		t.Fatalf("DeleteDirectLink: %v", err)
+	}
+	if _, ok := recorder.find(http.MethodDelete, "/contents/f1/directlinks/l1"); !ok {
+		t.Error("no DELETE request was sent")
+	}
+}
+
+func TestDirectLinkRejectsInvalidArguments(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	ctx := context.Background()
+
+	if _, err := client.CreateDirectLink(ctx, "", gofile.DirectLinkOptions{}); err == nil {
+		t.Error("CreateDirectLink succeeded without a content ID")
+	}
+	if _, err := client.UpdateDirectLink(ctx, "f1", "", gofile.DirectLinkOptions{}); err == nil {
+		t.Error("UpdateDirectLink succeeded without a direct link ID")
+	}
+	opts := gofile.DirectLinkOptions{Auth: []gofile.DirectLinkCredentials{{Username: "a:b", Password: "pass"}}}
+	if _, err := client.CreateDirectLink(ctx, "f1", opts); err == nil {
+		t.Error("CreateDirectLink accepted a username containing a colon")
+	}
+}
@@ -1,46 +1,203 @@
 package gofile
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"strings"
 )
 
+// maxErrorBodySize bounds the amount of an error response body
+// kept in an APIError.
+const maxErrorBodySize = 64 << 10
+
 // do sends an HTTP request using the underlying http.Client.
 //
-// The method automatically attaches the Authorization header,
-// logs the outgoing request, and validates the HTTP response.
+// The method automatically attaches the Authorization header when a token is known
+// and the configured User-Agent, sends every attempt through the middleware chain,
+// and validates the HTTP response. Every request is tagged with a request ID shared
+// by its attempts, which is logged and sent in the X-Request-Id header.
+//
+// Requests wait for the client-side rate limiter of their endpoint class.
+// Failed attempts are retried according to the client's RetryPolicy,
+// as long as the request body can be replayed and the context is not done.
+// Requests that are not idempotent are only retried if they did not reach the server.
+// Rate limited requests are resent once the limiter pause is over.
 //
 // It returns an error if:
 //   - the request fails at the transport level
 //   - the response status code is >= 400
 //   - the response content type indicates an HTML error page
+//   - the API response is JSON and its "status" field is not "ok"
+//
+// Errors reported by GoFile are returned as *APIError.
 //
 // On success, the caller is responsible for closing the response body.
 func (c *GofileClient) do(req *http.Request) (*http.Response, error) {
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	policy := c.loadRetryPolicy()
+	if retriesDisabled(req) {
+		policy = NoRetry()
+	}
+	req = req.WithContext(withRequestId(req.Context()))
+	rateLimited := 0
+	for attempt := 1; ; attempt++ {
+		resp, err := c.doAttempt(req)
+		if err == nil {
+			return resp, nil
+		}
+		// Rate limited attempts wait on the paused limiter and do not
+		// count against the retry policy.
+		waitLimiter := errors.Is(err, ErrRateLimited) && rateLimited < maxRateLimitedAttempts
+		if waitLimiter {
+			rateLimited++
+			attempt--
+		} else if attempt >= policy.MaxAttempts || !policy.retryable(err) || !policy.replayable(req, err) {
+			return nil, err
+		}
+
+		next, ok, rewindErr := rewindRequest(req)
+		if rewindErr != nil {
+			return nil, errors.Join(err, rewindErr)
+		}
+		if !ok {
+			return nil, err
+		}
+
+		if !waitLimiter {
+			delay := policy.backoff(attempt, err)
+			c.logger.LogAttrs(req.Context(), slog.LevelWarn, "Request failed, retrying",
+				slog.String("method", req.Method),
+				slog.String("endpoint", endpointOf(req.URL)),
+				slog.String("request_id", requestIdFrom(req.Context())),
+				slog.Int("attempt", attempt),
+				slog.Duration("delay", delay),
+				slog.Any("error", err),
+			)
+			if sleepErr := sleep(req.Context(), delay); sleepErr != nil {
+				closeRequestBody(next)
+				return nil, fmt.Errorf("%w, last error: %w", sleepErr, err)
+			}
+		} else {
+			c.logger.LogAttrs(req.Context(), slog.LevelInfo, "Request rate limited, waiting for the limiter",
+				slog.String("method", req.Method),
+				slog.String("endpoint", endpointOf(req.URL)),
+				slog.String("request_id", requestIdFrom(req.Context())),
+			)
+		}
+		req = next
+	}
+}
 
-	c.logger.Printf("Sending request to %s\n", req.URL.String())
+// doAttempt sends the request once through the rate limiter of its
+// endpoint class, pausing the class when GoFile asks the client to back off.
+func (c *GofileClient) doAttempt(req *http.Request) (*http.Response, error) {
+	class := endpointClassOf(req)
+	if err := c.limiter.wait(req.Context(), class); err != nil {
+		closeRequestBody(req)
+		return nil, fmt.Errorf("waiting for rate limiter: %w", err)
+	}
+
+	resp, err := c.send(req)
 
-	resp, err := c.client.Do(req)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.RetryAfter > 0 {
+			c.limiter.pause(class, apiErr.RetryAfter)
+		} else if errors.Is(apiErr, ErrRateLimited) {
+			c.limiter.pause(class, defaultRateLimitPause)
+		}
+	}
+	return resp, err
+}
+
+// send sends the request once through the middleware chain and validates the response.
+func (c *GofileClient) send(req *http.Request) (*http.Response, error) {
+	if token := c.token(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.doer.Do(req)
 	if err != nil {
+		// The transport closes the body on failure, but a middleware
+		// returning an error without calling it may not have.
+		closeRequestBody(req)
 		return nil, fmt.Errorf("sending request: %w", err)
 	}
 
 	// Check error responses
 	if strings.HasPrefix(resp.Header.Get(contentTypeHeader), "text/html") {
-		c.logger.Printf("Received HTML response body\n")
-		return nil, fmt.Errorf("received HTML response, possible error page")
+		c.logger.WarnContext(req.Context(), "Received HTML response body",
+			"method", req.Method,
+			"endpoint", endpointOf(req.URL),
+			"request_id", requestIdFrom(req.Context()),
+			"status", resp.StatusCode,
+		)
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		resp.Body.Close()
+		apiErr := newAPIError(req, resp, "", body)
+		apiErr.html = true
+		return nil, apiErr
 	}
 
 	if resp.StatusCode >= 400 {
-		bytes, err := io.ReadAll(resp.Body)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
 		if err != nil {
 			return nil, err
 		}
-		return nil, fmt.Errorf("received bad status: %s, body: %s", resp.Status, string(bytes))
+		return nil, newAPIError(req, resp, responseStatus(body), body)
+	}
+
+	// Downloaded files are streamed as-is, even when they are JSON documents.
+	isJson := strings.HasPrefix(resp.Header.Get(contentTypeHeader), applicationJsonContentType)
+	if isJson && !isFileDownload(req) {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading response body: %w", err)
+		}
+		if status := responseStatus(body); status != "" && status != statusOk {
+			return nil, newAPIError(req, resp, status, body)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
 	}
 
 	return resp, nil
 }
+
+// closeRequestBody closes the body of a request that will not be sent,
+// as the transport would have, so that the producer of a streamed body is released.
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+// fileDownloadContextKey marks the context of requests downloading file contents.
+type fileDownloadContextKey struct{}
+
+// isFileDownload reports whether req downloads file contents,
+// whose body is returned to the caller as-is.
+func isFileDownload(req *http.Request) bool {
+	download, _ := req.Context().Value(fileDownloadContextKey{}).(bool)
+	return download
+}
+
+// responseStatus extracts the "status" field of a GoFile JSON response body.
+// It returns an empty string if the body is not a JSON object.
+func responseStatus(body []byte) string {
+	var statusBody struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(body, &statusBody); err != nil {
+		return ""
+	}
+	return statusBody.Status
+}
@@ -3,7 +3,16 @@
 // The package exposes a single public client type that allows:
 //   - uploading files
 //   - creating folders
-//   - downloading files
+//   - listing folder contents
+//   - deleting files and folders
+//   - updating content attributes
+//   - copying and moving contents between folders
+//   - importing public contents into the account
+//   - managing direct download links
+//   - retrieving account information
+//   - uploading as a guest without an API key
+//   - searching contents within a folder tree
+//   - downloading files, including ranged, resumable and parallel downloads
 //   - retrieving file metadata
 //
 // All HTTP, caching, and request-building logic is internal to the package
@@ -11,6 +20,6 @@
 //
 // Usage example:
 //
-//	client := gofile.NewClient(apiKey, nil, nil)
+//	client, err := gofile.New(apiKey, nil, nil)
 //	resp, err := client.UploadFile(ctx, "root", "file.txt", reader)
 package gofile
@@ -0,0 +1,294 @@
+package gofile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// partialMetaSuffix is appended to the destination path of DownloadToFile
+// to store the validators of a partially downloaded file.
+const partialMetaSuffix = ".gofile-partial"
+
+// DownloadRange downloads length bytes of a file starting at offset
+// using an HTTP Range request. A length <= 0 reads up to the end of the file.
+//
+// It returns ErrRangeNotSupported if the server answers a partial range with
+// the whole file. Requesting the whole file, with offset 0 and length <= 0,
+// sends no Range header and accepts the plain response.
+// The caller is responsible for closing the returned ReadCloser.
+func (c *GofileClient) DownloadRange(
+	ctx context.Context,
+	server, fileId, fileName string,
+	offset, length int64,
+) (body io.ReadCloser, err error) {
+	defer c.observeStream("DownloadRange", time.Now(), &body, &err)
+
+	if offset < 0 {
+		return nil, fmt.Errorf("negative offset %d", offset)
+	}
+	resp, err := c.downloadRange(ctx, server, fileId, fileName, offset, length, "")
+	if err != nil {
+		return nil, err
+	}
+	body, err = rangeBody(resp, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	body = c.newThrottle(ctx, OperationDownload).reader(ctx, body)
+	tracker := c.newProgressTracker(ctx, OperationDownload, fileName, 0, resp.ContentLength)
+	return withProgress(body, tracker), nil
+}
+
+// rangeBody returns the body of a response to a request for the bytes from offset,
+// or ErrRangeNotSupported if a partial range was answered with the whole file.
+func rangeBody(resp *http.Response, offset, length int64) (io.ReadCloser, error) {
+	wholeFile := offset == 0 && length <= 0
+	if resp.StatusCode != http.StatusPartialContent && !wholeFile {
+		resp.Body.Close()
+		return nil, ErrRangeNotSupported
+	}
+	return resp.Body, nil
+}
+
+// DownloadToFile downloads a file into the local path, resuming a previous
+// partial download of the same file when possible.
+//
+// The size and validators (ETag, Last-Modified) of the partial download are
+// kept next to it until the download completes. A partial file is resumed
+// with an HTTP Range request only if it matches fileInfo and the remote file
+// is unchanged; otherwise the download starts over.
+//
+// It returns the size of the complete local file.
+func (c *GofileClient) DownloadToFile(ctx context.Context, fileInfo GetFileInfoResponseBody, path string) (_ int64, err error) {
+	defer c.observeOperation("DownloadToFile", time.Now(), &err)
+	server := fileInfo.Data.ServerSelected
+	if server == "" && len(fileInfo.Data.Servers) > 0 {
+		server = fileInfo.Data.Servers[0]
+	}
+	if server == "" {
+		return 0, fmt.Errorf("server is not specified")
+	}
+	if path == "" {
+		return 0, fmt.Errorf("path is not specified")
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return 0, fmt.Errorf("opening destination file: %w", err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("reading destination file size: %w", err)
+	}
+	offset := stat.Size()
+	metaPath := path + partialMetaSuffix
+	meta, metaErr := readPartialMeta(metaPath)
+	expectedSize := fileInfo.Data.Size
+
+	switch {
+	case offset == 0:
+	case metaErr != nil || !meta.matches(fileInfo):
+		c.logger.InfoContext(ctx, "Partial file does not match, restarting download", "path", path, "file_id", fileInfo.Data.Id)
+		offset = 0
+	case expectedSize > 0 && offset == expectedSize:
+		return offset, os.Remove(metaPath)
+	case expectedSize > 0 && offset > expectedSize:
+		offset = 0
+	}
+
+	resp, err := c.downloadRange(ctx, server, fileInfo.Data.Id, fileInfo.Data.Name, offset, 0, meta.validator())
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if offset > 0 && !resumable(resp, meta, offset, expectedSize) {
+		// The remote file changed or the server ignored the range:
+		// the body is the whole file, or it must be downloaded again.
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			resp, err = c.downloadRange(ctx, server, fileInfo.Data.Id, fileInfo.Data.Name, 0, 0, "")
+			if err != nil {
+				return 0, err
+			}
+			defer resp.Body.Close()
+		}
+		c.logger.InfoContext(ctx, "Remote file changed, restarting download", "path", path, "file_id", fileInfo.Data.Id)
+		offset = 0
+	}
+
+	if offset == 0 {
+		if err := file.Truncate(0); err != nil {
+			return 0, fmt.Errorf("truncating destination file: %w", err)
+		}
+	}
+	err = writePartialMeta(metaPath, partialMeta{
+		FileId:       fileInfo.Data.Id,
+		Size:         expectedSize,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("seeking destination file: %w", err)
+	}
+	tracker := c.newProgressTracker(ctx, OperationDownload, fileInfo.Data.Name, offset, expectedSize)
+	body := c.newThrottle(ctx, OperationDownload).reader(ctx, resp.Body)
+	written, err := io.Copy(file, withProgress(body, tracker))
+	tracker.finish()
+	total := offset + written
+	if err != nil {
+		return total, fmt.Errorf("downloading file, %d bytes kept for resuming: %w", total, err)
+	}
+	if expectedSize > 0 && total != expectedSize {
+		return total, fmt.Errorf("downloaded %d bytes, expected %d", total, expectedSize)
+	}
+	if err := file.Sync(); err != nil {
+		return total, fmt.Errorf("syncing destination file: %w", err)
+	}
+	return total, os.Remove(metaPath)
+}
+
+// downloadRange sends a GET request for the file, asking for the bytes from
+// offset when offset > 0 or length > 0. A non-empty ifRange validator makes
+// the server send the whole file instead if it changed.
+func (c *GofileClient) downloadRange(
+	ctx context.Context,
+	server, fileId, fileName string,
+	offset, length int64,
+	ifRange string,
+) (*http.Response, error) {
+
+	if server == "" {
+		return nil, fmt.Errorf("server is not specified")
+	}
+	if fileId == "" {
+		return nil, fmt.Errorf("fileId is not specified")
+	}
+	if fileName == "" {
+		return nil, fmt.Errorf("fileName is not specified")
+	}
+
+	req, err := c.createGetFileRequest(ctx, server, fileId, fileName)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 || length > 0 {
+		byteRange := fmt.Sprintf("bytes=%d-", offset)
+		if length > 0 {
+			byteRange += strconv.FormatInt(offset+length-1, 10)
+		}
+		req.Header.Set("Range", byteRange)
+		if ifRange != "" {
+			req.Header.Set("If-Range", ifRange)
+		}
+	}
+	return c.do(req)
+}
+
+// resumable reports whether a response continues the partial download
+// at offset without the remote file having changed.
+func resumable(resp *http.Response, meta partialMeta, offset, expectedSize int64) bool {
+	if resp.StatusCode != http.StatusPartialContent {
+		return false
+	}
+	start, total, ok := parseContentRange(resp.Header.Get("Content-Range"))
+	if !ok || start != offset {
+		return false
+	}
+	if expectedSize > 0 && total >= 0 && total != expectedSize {
+		return false
+	}
+	if etag := resp.Header.Get("ETag"); meta.ETag != "" && etag != "" && etag != meta.ETag {
+		return false
+	}
+	if lastModified := resp.Header.Get("Last-Modified"); meta.LastModified != "" && lastModified != "" && lastModified != meta.LastModified {
+		return false
+	}
+	return true
+}
+
+// parseContentRange parses a "bytes start-end/total" header.
+// The total is -1 when the server does not know it.
+func parseContentRange(value string) (start, total int64, ok bool) {
+	spec, found := strings.CutPrefix(value, "bytes ")
+	if !found {
+		return 0, 0, false
+	}
+	byteRange, totalValue, found := strings.Cut(spec, "/")
+	if !found {
+		return 0, 0, false
+	}
+	startValue, _, found := strings.Cut(byteRange, "-")
+	if !found {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(startValue, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if totalValue == "*" {
+		return start, -1, true
+	}
+	total, err = strconv.ParseInt(totalValue, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, total, true
+}
+
+// partialMeta describes the remote file a partial download belongs to.
+type partialMeta struct {
+	FileId       string `json:"fileId"`
+	Size         int64  `json:"size"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// matches reports whether the partial download belongs to the described file.
+func (m partialMeta) matches(fileInfo GetFileInfoResponseBody) bool {
+	return m.FileId == fileInfo.Data.Id && (fileInfo.Data.Size <= 0 || m.Size == fileInfo.Data.Size)
+}
+
+// validator returns the value of the If-Range header, preferring a strong ETag.
+func (m partialMeta) validator() string {
+	if m.ETag != "" && !strings.HasPrefix(m.ETag, "W/") {
+		return m.ETag
+	}
+	return m.LastModified
+}
+
+func readPartialMeta(path string) (partialMeta, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return partialMeta{}, err
+	}
+	var meta partialMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return partialMeta{}, fmt.Errorf("decoding partial download metadata: %w", err)
+	}
+	return meta, nil
+}
+
+func writePartialMeta(path string, meta partialMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("encoding partial download metadata: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing partial download metadata: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package gofile_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gofile "github.com/yaGatito/gofile-client"
+	"github.com/yaGatito/gofile-client/gofiletest"
+)
+
+func TestParseContentRange(t *testing.T) {
+	tests := []struct {
+		value        string
+		start, total int64
+		ok           bool
+	}{
+		{value: "bytes 0-99/200", start: 0, total: 200, ok: true},
+		{value: "bytes 100-199/200", start: 100, total: 200, ok: true},
+		{value: "bytes 5-9/*", start: 5, total: -1, ok: true},
+		{value: "", ok: false},
+		{value: "bytes */200", ok: false},
+		{value: "items 0-9/10", ok: false},
+		{value: "bytes 0-9", ok: false},
+		{value: "bytes x-9/10", ok: false},
+		{value: "bytes 0-9/x", ok: false},
+	}
+	for _, tt := range tests {
+		start, total, ok := gofile.ParseContentRange(tt.value)
+		if ok != tt.ok || (ok && (start != tt.start || total != tt.total)) {
+			t.Errorf("ParseContentRange(%q) = %d, %d, %t, want %d, %d, %t",
+				tt.value, start, total, ok, tt.start, tt.total, tt.ok)
+		}
+	}
+}
+
+func TestDownloadRange(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	data := testData(1000)
+	uploaded := uploadTestFile(t, client, srv, "data.bin", data)
+
+	tests := []struct {
+		name           string
+		offset, length int64
+		want           []byte
+	}{
+		{name: "bounded", offset: 10, length: 20, want: data[10:30]},
+		{name: "to end", offset: 900, length: 0, want: data[900:]},
+		{name: "whole file", offset: 0, length: 0, want: data},
+		{name: "first bytes", offset: 0, length: 1, want: data[:1]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := client.DownloadRange(context.Background(), gofiletest.StoreServer, uploaded.Data.Id, "data.bin", tt.offset, tt.length)
+			if err != nil {
+				t.Fatalf("DownloadRange: %v", err)
+			}
+			defer body.Close()
+			got, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("reading range: %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("got %d bytes, want %d matching bytes", len(got), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestDownloadRangeUnsupported(t *testing.T) {
+	srv := newTestServer(t)
+	// Dropping the Range header makes the server answer with the whole file.
+	dropRange := gofile.HookMiddleware(func(req *http.Request) error {
+		req.Header.Del("Range")
+		return nil
+	}, nil)
+	client := newTestClient(t, srv, gofile.WithMiddleware(dropRange))
+	uploaded := uploadTestFile(t, client, srv, "data.bin", testData(100))
+
+	_, err := client.DownloadRange(context.Background(), gofiletest.StoreServer, uploaded.Data.Id, "data.bin", 10, 10)
+	if !errors.Is(err, gofile.ErrRangeNotSupported) {
+		t.Fatalf("got error %v, want ErrRangeNotSupported", err)
+	}
+}
+
+func TestDownloadToFileResumes(t *testing.T) {
+	srv := newTestServer(t)
+	data := testData(64 << 10)
+
+	// The first download fails after half of the file.
+	var failDownloads bool
+	var ranges []string
+	interrupt := func(next gofile.Doer) gofile.Doer {
+		return gofile.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			ranges = append(ranges, req.Header.Get("Range"))
+			resp, err := next.Do(req)
+			if err == nil && failDownloads && strings.Contains(req.URL.Path, "/download/") {
+				resp.Body = &failingReader{r: resp.Body, remaining: int64(len(data) / 2)}
+			}
+			return resp, err
+		})
+	}
+	client := newTestClient(t, srv, gofile.WithMiddleware(interrupt), gofile.WithRetryPolicy(gofile.NoRetry()))
+	uploaded := uploadTestFile(t, client, srv, "data.bin", data)
+	info, err := client.GetFileInfo(context.Background(), "", uploaded.Data.Id)
+	if err != nil {
+		t.Fatalf("GetFileInfo: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "data.bin")
+
+	failDownloads = true
+	ranges = nil
+	written, err := client.DownloadToFile(context.Background(), info, path)
+	if !errors.Is(err, errInterrupted) {
+		t.Fatalf("got error %v, want the interruption", err)
+	}
+	if written != int64(len(data)/2) {
+		t.Fatalf("kept %d bytes, want %d", written, len(data)/2)
+	}
+
+	failDownloads = false
+	ranges = nil
+	written, err = client.DownloadToFile(context.Background(), info, path)
+	if err != nil {
+		t.Fatalf("resuming DownloadToFile: %v", err)
+	}
+	if written != int64(len(data)) {
+		t.Errorf("got %d bytes, want %d", written, len(data))
+	}
+	if want := []string{"bytes=32768-"}; len(ranges) != 1 || ranges[0] != want[0] {
+		t.Errorf("sent ranges %q, want %q", ranges, want)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Error("resumed file differs from the uploaded one")
+	}
+	if _, err := os.Stat(path + ".gofile-partial"); !os.IsNotExist(err) {
+		t.Errorf("partial download metadata kept after completion: %v", err)
+	}
+}
+
+var errInterrupted = errors.New("connection interrupted")
+
+// failingReader fails with errInterrupted after remaining bytes.
+type failingReader struct {
+	r         io.ReadCloser
+	remaining int64
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	if f.remaining <= 0 {
+		return 0, errInterrupted
+	}
+	if int64(len(p)) > f.remaining {
+		p = p[:f.remaining]
+	}
+	n, err := f.r.Read(p)
+	f.remaining -= int64(n)
+	return n, err
+}
+
+func (f *failingReader) Close() error {
+	return f.r.Close()
+}
@@ -1,6 +1,10 @@
 package gofile
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"time"
+)
 
 // Public Models
 type CreateFolderResponseBody struct {
@@ -25,6 +29,7 @@ type UploadFileResponseBody struct {
 	Data   struct {
 		CreateTime       int64    `json:"createTime"`
 		DownloadPage     string   `json:"downloadPage"`
+		GuestToken       string   `json:"guestToken"`
 		Id               string   `json:"id"`
 		Md5              string   `json:"md5"`
 		Mimetype         string   `json:"mimetype"`
@@ -65,20 +70,248 @@ func (u GetFileInfoResponseBody) String() string {
 		u.Status, u.Data.Id, u.Data.Name, u.Data.Md5, u.Data.Size, u.Data.Type, u.Data.Mimetype, u.Data.CreateTime, u.Data.ParentFolderId, u.Data.DownloadPage)
 }
 
+type GetFolderContentsResponseBody struct {
+	Status string `json:"status"`
+	Data   struct {
+		Id                 string                 `json:"id"`
+		Type               string                 `json:"type"`
+		Name               string                 `json:"name"`
+		ParentFolderId     string                 `json:"parentFolder"`
+		Code               string                 `json:"code"`
+		CreateTime         int64                  `json:"createTime"`
+		Public             bool                   `json:"public"`
+		TotalSize          int64                  `json:"totalSize"`
+		TotalDownloadCount int64                  `json:"totalDownloadCount"`
+		ChildrenCount      int                    `json:"childrenCount"`
+		Children           map[string]FolderChild `json:"children"`
+	} `json:"data"`
+}
+
+func (g GetFolderContentsResponseBody) String() string {
+	return fmt.Sprintf("Status: %s; Data.Id: %s; Data.Name: %s; Data.Code: %s; Data.CreateTime: %d; Data.ParentFolderId: %s; Data.TotalSize: %d; Data.ChildrenCount: %d",
+		g.Status, g.Data.Id, g.Data.Name, g.Data.Code, g.Data.CreateTime, g.Data.ParentFolderId, g.Data.TotalSize, g.Data.ChildrenCount)
+}
+
+// Files returns the children of the folder that are files.
+func (g GetFolderContentsResponseBody) Files() []FolderChild {
+	return g.childrenOfType(ContentTypeFile)
+}
+
+// Folders returns the children of the folder that are subfolders.
+func (g GetFolderContentsResponseBody) Folders() []FolderChild {
+	return g.childrenOfType(ContentTypeFolder)
+}
+
+func (g GetFolderContentsResponseBody) childrenOfType(contentType string) []FolderChild {
+	var children []FolderChild
+	for _, child := range g.Data.Children {
+		if child.Type == contentType {
+			children = append(children, child)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].Name < children[j].Name
+	})
+	return children
+}
+
+// FolderChild is a single file or subfolder listed in a folder.
+//
+// File-only fields (Size, Md5, Mimetype, Servers) are empty for subfolders,
+// and ChildrenCount is zero for files.
+type FolderChild struct {
+	Id             string   `json:"id"`
+	Type           string   `json:"type"`
+	Name           string   `json:"name"`
+	ParentFolderId string   `json:"parentFolder"`
+	Code           string   `json:"code"`
+	CreateTime     int64    `json:"createTime"`
+	Size           int64    `json:"size"`
+	DownloadCount  int64    `json:"downloadCount"`
+	Md5            string   `json:"md5"`
+	Mimetype       string   `json:"mimetype"`
+	Servers        []string `json:"servers"`
+	DownloadPage   string   `json:"link"`
+	ChildrenCount  int      `json:"childrenCount"`
+}
+
+func (f FolderChild) String() string {
+	return fmt.Sprintf("Id: %s; Type: %s; Name: %s; Size: %d; Md5: %s; CreateTime: %d; DownloadCount: %d",
+		f.Id, f.Type, f.Name, f.Size, f.Md5, f.CreateTime, f.DownloadCount)
+}
+
+// ContentsOperationResponseBody is returned by operations acting on several
+// contents at once. Data holds a result keyed by each requested content ID.
+type ContentsOperationResponseBody struct {
+	Status string                   `json:"status"`
+	Data   map[string]ContentResult `json:"data"`
+}
+
+// Succeeded returns the sorted IDs of contents the operation was applied to.
+func (c ContentsOperationResponseBody) Succeeded() []string {
+	return c.idsWhere(func(r ContentResult) bool { return r.Status == statusOk })
+}
+
+// Failed returns the sorted IDs of contents the operation could not be applied to.
+func (c ContentsOperationResponseBody) Failed() []string {
+	return c.idsWhere(func(r ContentResult) bool { return r.Status != statusOk })
+}
+
+func (c ContentsOperationResponseBody) idsWhere(match func(ContentResult) bool) []string {
+	var ids []string
+	for id, result := range c.Data {
+		if match(result) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (c ContentsOperationResponseBody) String() string {
+	return fmt.Sprintf("Status: %s; Succeeded: %v; Failed: %v", c.Status, c.Succeeded(), c.Failed())
+}
+
+// NewIds maps the requested content IDs to the IDs of the contents created
+// by the operation, such as copies or imports. Results without a new ID are omitted.
+func (c ContentsOperationResponseBody) NewIds() map[string]string {
+	newIds := make(map[string]string)
+	for id, result := range c.Data {
+		if result.Status == statusOk && result.Data.Id != "" {
+			newIds[id] = result.Data.Id
+		}
+	}
+	return newIds
+}
+
+// ContentResult is the outcome of an operation for a single content ID.
+//
+// Data.Id holds the ID of the created content for operations producing new contents.
+type ContentResult struct {
+	Status string `json:"status"`
+	Data   struct {
+		Id string `json:"id"`
+	} `json:"data"`
+}
+
+// DirectLink is a direct download URL of a file or folder
+// together with its access restrictions.
+type DirectLink struct {
+	Id               string   `json:"id"`
+	DirectLink       string   `json:"directLink"`
+	ExpireTime       int64    `json:"expireTime"`
+	SourceIpsAllowed []string `json:"sourceIpsAllowed"`
+	DomainsAllowed   []string `json:"domainsAllowed"`
+	Auth             []string `json:"auth"`
+	IsReqLink        bool     `json:"isReqLink"`
+}
+
+// Expires returns the expiry moment of the link, or the zero time if it never expires.
+func (d DirectLink) Expires() time.Time {
+	if d.ExpireTime == 0 {
+		return time.Time{}
+	}
+	return time.Unix(d.ExpireTime, 0)
+}
+
+func (d DirectLink) String() string {
+	return fmt.Sprintf("Id: %s; DirectLink: %s; ExpireTime: %d; SourceIpsAllowed: %v; DomainsAllowed: %v; Auth: %d credential(s)",
+		d.Id, d.DirectLink, d.ExpireTime, d.SourceIpsAllowed, d.DomainsAllowed, len(d.Auth))
+}
+
+// Account describes the GoFile account associated with the API key.
+//
+// Storage is expressed in bytes, as are the traffic statistics
+// of the current period.
+type Account struct {
+	Id                     string
+	Email                  string
+	Tier                   string
+	RootFolderId           string
+	FolderCount            int
+	FileCount              int
+	Storage                int64
+	TrafficDirectGenerated int64
+	TrafficReqDownloaded   int64
+	TrafficWebDownloaded   int64
+}
+
+// IsPremium reports whether the account tier grants access to premium-only endpoints.
+func (a Account) IsPremium() bool {
+	return a.Tier != "" && a.Tier != TierGuest && a.Tier != TierStandard
+}
+
+func (a Account) String() string {
+	return fmt.Sprintf("Id: %s; Email: %s; Tier: %s; RootFolderId: %s; FolderCount: %d; FileCount: %d; Storage: %d",
+		a.Id, a.Email, a.Tier, a.RootFolderId, a.FolderCount, a.FileCount, a.Storage)
+}
+
 // Private Models
 type createFolderRequestBody struct {
 	ParentFolderId string `json:"parentFolderId"`
 	FolderName     string `json:"folderName,omitempty"`
 }
 
+type contentsRequestBody struct {
+	ContentsId string `json:"contentsId"`
+	FolderId   string `json:"folderId,omitempty"`
+}
+
+type updateContentRequestBody struct {
+	Attribute      string `json:"attribute"`
+	AttributeValue any    `json:"attributeValue"`
+}
+
+type directLinkRequestBody struct {
+	ExpireTime       int64    `json:"expireTime,omitempty"`
+	SourceIpsAllowed []string `json:"sourceIpsAllowed,omitempty"`
+	DomainsAllowed   []string `json:"domainsAllowed,omitempty"`
+	Auth             []string `json:"auth,omitempty"`
+}
+
+type updateDirectLinkRequestBody struct {
+	ExpireTime       int64    `json:"expireTime"`
+	SourceIpsAllowed []string `json:"sourceIpsAllowed"`
+	DomainsAllowed   []string `json:"domainsAllowed"`
+	Auth             []string `json:"auth"`
+}
+
+type directLinkResponseData struct {
+	Status string     `json:"status"`
+	Data   DirectLink `json:"data"`
+}
+
+type listDirectLinksResponseData struct {
+	Status string `json:"status"`
+	Data   struct {
+		DirectLinks map[string]DirectLink `json:"directLinks"`
+	} `json:"data"`
+}
+
+type searchResponseData struct {
+	Status string                 `json:"status"`
+	Data   map[string]FolderChild `json:"data"`
+}
+
+type getServersResponseData struct {
+	Status string `json:"status"`
+	Data   struct {
+		Servers        []Server `json:"servers"`
+		ServersAllZone []Server `json:"serversAllZone"`
+	} `json:"data"`
+}
+
 type getAccountInfoResponseData struct {
 	Status string `json:"status"`
 	Data   struct {
 		RootFolder string `json:"rootFolder"`
 		Stats      struct {
-			FolderCount int `json:"folderCount"`
-			FileCount   int `json:"fileCount"`
-			Storage     int `json:"storage"`
+			FolderCount            int   `json:"folderCount"`
+			FileCount              int   `json:"fileCount"`
+			Storage                int64 `json:"storage"`
+			TrafficDirectGenerated int64 `json:"trafficDirectGenerated"`
+			TrafficReqDownloaded   int64 `json:"trafficReqDownloaded"`
+			TrafficWebDownloaded   int64 `json:"trafficWebDownloaded"`
 		} `json:"statsCurrent"`
 		Email string `json:"email"`
 	} `json:"data"`
@@ -91,4 +324,4 @@ type getIdResponseData struct {
 		Tier  string `json:"tier"`
 		Email string `json:"email"`
 	} `json:"data"`
-}
\ No newline at end of file
+}
@@ -0,0 +1,58 @@
+package gofile
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// contentsEndpoint returns the URL of the bulk contents endpoint.
+func (c *GofileClient) contentsEndpoint() string {
+	return c.apiBaseURL + "/contents"
+}
+
+// contentsBaseURL returns the prefix of the per-content endpoints.
+func (c *GofileClient) contentsBaseURL() string {
+	return c.apiBaseURL + "/contents/"
+}
+
+// accountsBaseURL returns the prefix of the account endpoints.
+func (c *GofileClient) accountsBaseURL() string {
+	return c.apiBaseURL + "/accounts/"
+}
+
+// postFolderEndpoint returns the URL of the folder creation endpoint.
+func (c *GofileClient) postFolderEndpoint() string {
+	return c.contentsBaseURL() + "createFolder"
+}
+
+// postFileEndpoint returns the URL files are uploaded to.
+func (c *GofileClient) postFileEndpoint() string {
+	return c.uploadURL
+}
+
+// validateEndpoints checks the configured endpoints are usable.
+func (c *GofileClient) validateEndpoints() error {
+	for name, endpoint := range map[string]string{
+		"API base URL": c.apiBaseURL,
+		"upload URL":   c.uploadURL,
+	} {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid %s %q: absolute URL expected", name, endpoint)
+		}
+	}
+	if strings.Count(c.downloadURLTemplate, "%s") != 3 || strings.Count(c.downloadURLTemplate, "%") != 3 {
+		return fmt.Errorf("invalid download URL template %q: exactly three %%s verbs expected for server, file ID and file name", c.downloadURLTemplate)
+	}
+	if c.uploadServers != nil {
+		template := c.uploadServers.selection.URLTemplate
+		if strings.Count(template, "%s") != 1 || strings.Count(template, "%") != 1 {
+			return fmt.Errorf("invalid upload server URL template %q: exactly one %%s verb expected for the server", template)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package gofile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors matched by APIError through errors.Is.
+var (
+	ErrNotFound        = errors.New("gofile: content not found")
+	ErrRateLimited     = errors.New("gofile: rate limited")
+	ErrPremiumRequired = errors.New("gofile: premium account required")
+	ErrUnauthorized    = errors.New("gofile: unauthorized")
+	ErrColdStorage     = errors.New("gofile: content is in cold storage")
+	ErrHTMLResponse    = errors.New("gofile: unexpected HTML response")
+)
+
+// ErrRangeNotSupported is returned by DownloadRange when the server
+// ignores the requested range and answers with the whole file.
+var ErrRangeNotSupported = errors.New("gofile: server does not support range requests")
+
+// APIError is returned when GoFile rejects a request, either with an HTTP
+// error status, an HTML error page, or a JSON body whose status is not "ok".
+//
+// Use errors.Is with the package sentinels to classify it,
+// e.g. errors.Is(err, gofile.ErrNotFound).
+type APIError struct {
+	// HTTPStatus is the HTTP status code of the response.
+	HTTPStatus int
+	// Status is the "status" field of the GoFile response body, e.g. "error-notFound".
+	// It is empty when the body is not a GoFile JSON response.
+	Status string
+	// Method is the HTTP method of the request.
+	Method string
+	// Endpoint is the request URL without its query string.
+	Endpoint string
+	// Body is the raw response body, possibly truncated.
+	Body []byte
+	// RetryAfter is the delay requested by the Retry-After response header, if any.
+	RetryAfter time.Duration
+
+	html bool
+}
+
+func (e *APIError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "gofile: %s %s: %d %s", e.Method, e.Endpoint, e.HTTPStatus, http.StatusText(e.HTTPStatus))
+	if e.Status != "" {
+		fmt.Fprintf(&sb, " (%s)", e.Status)
+	}
+	if e.html {
+		sb.WriteString(": received HTML response, possible error page")
+	} else if len(e.Body) > 0 {
+		fmt.Fprintf(&sb, ", body: %s", e.Body)
+	}
+	return sb.String()
+}
+
+// Is reports whether the error belongs to the class of the target sentinel.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrHTMLResponse:
+		return e.html
+	case ErrNotFound:
+		return e.hasStatus("error-notfound") || e.HTTPStatus == http.StatusNotFound
+	case ErrRateLimited:
+		return e.hasStatus("error-ratelimit") || e.HTTPStatus == http.StatusTooManyRequests
+	case ErrPremiumRequired:
+		return e.hasStatus("error-notpremium")
+	case ErrUnauthorized:
+		return e.hasStatus("error-token", "error-notauthorized", "error-auth") ||
+			e.HTTPStatus == http.StatusUnauthorized
+	case ErrColdStorage:
+		return strings.Contains(strings.ToLower(e.Status), "coldstorage")
+	}
+	return false
+}
+
+// hasStatus reports whether the GoFile status matches any of the given
+// statuses, ignoring case.
+func (e *APIError) hasStatus(statuses ...string) bool {
+	for _, status := range statuses {
+		if strings.EqualFold(e.Status, status) {
+			return true
+		}
+	}
+	return false
+}
+
+// isServerFailure reports whether err means the server itself is unhealthy:
+// a transport error, a 5xx response or an HTML error page.
+func isServerFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.html || apiErr.HTTPStatus >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// newAPIError builds an APIError describing the response to req.
+func newAPIError(req *http.Request, resp *http.Response, status string, body []byte) *APIError {
+	return &APIError{
+		HTTPStatus: resp.StatusCode,
+		Status:     status,
+		Method:     req.Method,
+		Endpoint:   endpointOf(req.URL),
+		Body:       body,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds
+// or as an HTTP date. It returns zero if the header is absent or invalid.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
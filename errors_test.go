@@ -0,0 +1,78 @@
+package gofile_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	gofile "github.com/yaGatito/gofile-client"
+	"github.com/yaGatito/gofile-client/gofiletest"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	sentinels := []error{
+		gofile.ErrNotFound,
+		gofile.ErrRateLimited,
+		gofile.ErrPremiumRequired,
+		gofile.ErrUnauthorized,
+		gofile.ErrColdStorage,
+		gofile.ErrHTMLResponse,
+	}
+	tests := []struct {
+		name string
+		err  *gofile.APIError
+		want error // nil when no sentinel matches
+	}{
+		{"404", &gofile.APIError{HTTPStatus: http.StatusNotFound}, gofile.ErrNotFound},
+		{"200 not found status", &gofile.APIError{HTTPStatus: http.StatusOK, Status: "error-notFound"}, gofile.ErrNotFound},
+		{"not found status case", &gofile.APIError{HTTPStatus: http.StatusBadRequest, Status: "ERROR-NOTFOUND"}, gofile.ErrNotFound},
+		{"429", &gofile.APIError{HTTPStatus: http.StatusTooManyRequests}, gofile.ErrRateLimited},
+		{"rate limit status", &gofile.APIError{HTTPStatus: http.StatusOK, Status: "error-rateLimit"}, gofile.ErrRateLimited},
+		{"not premium status", &gofile.APIError{HTTPStatus: http.StatusForbidden, Status: "error-notPremium"}, gofile.ErrPremiumRequired},
+		{"401", &gofile.APIError{HTTPStatus: http.StatusUnauthorized}, gofile.ErrUnauthorized},
+		{"token status", &gofile.APIError{HTTPStatus: http.StatusOK, Status: "error-token"}, gofile.ErrUnauthorized},
+		{"not authorized status", &gofile.APIError{HTTPStatus: http.StatusForbidden, Status: "error-notAuthorized"}, gofile.ErrUnauthorized},
+		{"auth status", &gofile.APIError{HTTPStatus: http.StatusOK, Status: "error-auth"}, gofile.ErrUnauthorized},
+		{"cold storage status", &gofile.APIError{HTTPStatus: http.StatusOK, Status: "error-coldStorage"}, gofile.ErrColdStorage},
+		{"500", &gofile.APIError{HTTPStatus: http.StatusInternalServerError}, nil},
+		{"403 without status", &gofile.APIError{HTTPStatus: http.StatusForbidden}, nil},
+		{"unknown status", &gofile.APIError{HTTPStatus: http.StatusOK, Status: "error-wrongParameters"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, sentinel := range sentinels {
+				if got := errors.Is(tt.err, sentinel); got != (sentinel == tt.want) {
+					t.Errorf("errors.Is(%v, %v) = %t, want %t", tt.err, sentinel, got, !got)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIErrorFromOkResponseWithErrorStatus(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	recorder.stub(http.MethodGet, "/contents/f1", `{"status":"error-notFound","data":{}}`)
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+
+	_, err := client.GetFolderContents(context.Background(), "f1")
+	var apiErr *gofile.APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, gofile.ErrNotFound) {
+		t.Fatalf("got error %v, want an *APIError matching ErrNotFound", err)
+	}
+	if apiErr.HTTPStatus != http.StatusOK || apiErr.Status != "error-notFound" || apiErr.Method != http.MethodGet {
+		t.Errorf("got %+v, want a GET answered 200 with status error-notFound", apiErr)
+	}
+}
+
+func TestAPIErrorFromHTMLPage(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv, gofile.WithRetryPolicy(gofile.NoRetry()))
+	srv.InjectFault(gofiletest.Fault{Kind: gofiletest.FaultHTMLPage, PathPrefix: "/accounts/getid"})
+
+	_, err := client.GetAccount(context.Background())
+	if !errors.Is(err, gofile.ErrHTMLResponse) || errors.Is(err, gofile.ErrNotFound) {
+		t.Errorf("got error %v, want only ErrHTMLResponse", err)
+	}
+}
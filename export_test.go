@@ -0,0 +1,26 @@
+package gofile
+
+import (
+	"context"
+	"time"
+)
+
+// Internals exposed to the external tests of the package.
+
+var ParseContentRange = parseContentRange
+
+type TokenBucket = tokenBucket
+
+var NewTokenBucket = newTokenBucket
+
+func (b *tokenBucket) Wait(ctx context.Context, n float64) error {
+	return b.wait(ctx, n)
+}
+
+func (b *tokenBucket) SetRate(rate, burst float64) {
+	b.setRate(rate, burst)
+}
+
+func (b *tokenBucket) Pause(until time.Time) {
+	b.pause(until)
+}
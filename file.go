@@ -3,14 +3,17 @@ package gofile
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 // GetFileInfo retrieves metadata information for the specified file.
-func (c *GofileClient) GetFileInfo(ctx context.Context, websiteToken, fileId string) (GetFileInfoResponseBody, error) {
+func (c *GofileClient) GetFileInfo(ctx context.Context, websiteToken, fileId string) (_ GetFileInfoResponseBody, err error) {
+	defer c.observeOperation("GetFileInfo", time.Now(), &err)
 	req, err := c.createGetFileInfoRequest(ctx, websiteToken, fileId)
 	if err != nil {
 		return GetFileInfoResponseBody{}, err
@@ -32,7 +35,8 @@ func (c *GofileClient) GetFileInfo(ctx context.Context, websiteToken, fileId str
 // DownloadFile downloads a file from the specified GoFile server.
 //
 // The caller is responsible for closing the returned ReadCloser.
-func (c *GofileClient) DownloadFile(ctx context.Context, server, fileId, fileName string) (io.ReadCloser, error) {
+func (c *GofileClient) DownloadFile(ctx context.Context, server, fileId, fileName string) (body io.ReadCloser, err error) {
+	defer c.observeStream("DownloadFile", time.Now(), &body, &err)
 	if server == "" {
 		return nil, fmt.Errorf("server is not specified")
 	}
@@ -52,14 +56,100 @@ func (c *GofileClient) DownloadFile(ctx context.Context, server, fileId, fileNam
 		return nil, err
 	}
 
-	return response.Body, nil
+	body = c.newThrottle(ctx, OperationDownload).reader(ctx, response.Body)
+	tracker := c.newProgressTracker(ctx, OperationDownload, fileName, 0, response.ContentLength)
+	return withProgress(body, tracker), nil
+}
+
+// DownloadFileVerified downloads the file described by fileInfo from its
+// selected server and verifies it while it is read.
+//
+// The bytes are hashed as they are consumed. At EOF their count and MD5 sum
+// are checked against fileInfo.Data.Size and fileInfo.Data.Md5; a mismatch,
+// such as a truncated download, is returned as an *IntegrityError by Read
+// instead of io.EOF, and by Close. Empty expected values are not checked.
+//
+// The caller is responsible for closing the returned ReadCloser.
+func (c *GofileClient) DownloadFileVerified(ctx context.Context, fileInfo GetFileInfoResponseBody) (body io.ReadCloser, err error) {
+	defer c.observeStream("DownloadFileVerified", time.Now(), &body, &err)
+	body, err = c.DownloadFile(ctx, fileInfo.Data.ServerSelected, fileInfo.Data.Id, fileInfo.Data.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &verifyingReader{
+		ReadCloser:   body,
+		contentId:    fileInfo.Data.Id,
+		expectedMd5:  fileInfo.Data.Md5,
+		expectedSize: fileInfo.Data.Size,
+		counter:      newMd5Counter(),
+	}, nil
+}
+
+// DownloadFileFromServers downloads a file trying each of the given servers in turn.
+//
+// The serverSelected is tried first, followed by the remaining servers in order,
+// typically GetFileInfoResponseBody.Data.ServerSelected and .Servers.
+// The next server is tried when a server fails at the transport level,
+// answers with a 5xx status or returns an HTML error page; other errors are
+// returned immediately.
+//
+// It returns the body along with the name of the server that served it.
+// The caller is responsible for closing the returned ReadCloser.
+func (c *GofileClient) DownloadFileFromServers(
+	ctx context.Context,
+	serverSelected string,
+	servers []string,
+	fileId, fileName string,
+) (body io.ReadCloser, _ string, err error) {
+	defer c.observeStream("DownloadFileFromServers", time.Now(), &body, &err)
+	if fileId == "" {
+		return nil, "", fmt.Errorf("fileId is not specified")
+	}
+	if fileName == "" {
+		return nil, "", fmt.Errorf("fileName is not specified")
+	}
+
+	candidates := downloadServerOrder(serverSelected, servers)
+	if len(candidates) == 0 {
+		return nil, "", fmt.Errorf("no server specified")
+	}
+
+	var errs []error
+	for _, server := range candidates {
+		body, err := c.DownloadFile(ctx, server, fileId, fileName)
+		if err == nil {
+			return body, server, nil
+		}
+		if !isServerFailure(err) {
+			return nil, "", err
+		}
+		c.logger.WarnContext(ctx, "Download failed, trying next server", "server", server, "file_id", fileId, "error", err)
+		errs = append(errs, fmt.Errorf("server %s: %w", server, err))
+	}
+	return nil, "", fmt.Errorf("all %d servers failed: %w", len(candidates), errors.Join(errs...))
+}
+
+// downloadServerOrder returns the servers to try, starting with serverSelected,
+// without empty names and duplicates.
+func downloadServerOrder(serverSelected string, servers []string) []string {
+	seen := make(map[string]bool)
+	var order []string
+	for _, server := range append([]string{serverSelected}, servers...) {
+		if server == "" || seen[server] {
+			continue
+		}
+		seen[server] = true
+		order = append(order, server)
+	}
+	return order
 }
 
 // createGetFileRequest builds an HTTP GET request for getting a file
 // with the specified server, fieldId, name.
 func (c *GofileClient) createGetFileRequest(ctx context.Context, server, fileId, fileName string) (*http.Request, error) {
-	url := fmt.Sprintf(getFileEndpoint, server, url.PathEscape(fileId), url.PathEscape(fileName))
+	url := fmt.Sprintf(c.downloadURLTemplate, server, url.PathEscape(fileId), url.PathEscape(fileName))
 
+	ctx = context.WithValue(ctx, fileDownloadContextKey{}, true)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating 'getFile' request: %w", err)
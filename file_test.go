@@ -0,0 +1,189 @@
+package gofile_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	gofile "github.com/yaGatito/gofile-client"
+	"github.com/yaGatito/gofile-client/gofiletest"
+)
+
+func TestDownloadFile(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	data := testData(100 << 10)
+	uploaded := uploadTestFile(t, client, srv, "data.bin", data)
+
+	body, err := client.DownloadFile(context.Background(), gofiletest.StoreServer, uploaded.Data.Id, "data.bin")
+	if err != nil {
+		t.Fatalf("DownloadFile: %v", err)
+	}
+	defer body.Close()
+	got, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("reading download: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Error("downloaded bytes differ from the uploaded ones")
+	}
+}
+
+func TestDownloadFileStreamsJSONFiles(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	data := []byte(`{"status":"error-notFound"}`)
+	uploaded := uploadTestFile(t, client, srv, "status.json", data)
+
+	body, err := client.DownloadFile(context.Background(), gofiletest.StoreServer, uploaded.Data.Id, "status.json")
+	if err != nil {
+		t.Fatalf("DownloadFile: %v", err)
+	}
+	defer body.Close()
+	got, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("reading download: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("got %q, want %q", got, data)
+	}
+}
+
+func TestDownloadFileVerified(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	data := testData(10 << 10)
+	uploaded := uploadTestFile(t, client, srv, "data.bin", data)
+	info, err := client.GetFileInfo(context.Background(), "", uploaded.Data.Id)
+	if err != nil {
+		t.Fatalf("GetFileInfo: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		md5     string
+		size    int64
+		wantErr bool
+	}{
+		{name: "valid", md5: info.Data.Md5, size: info.Data.Size},
+		{name: "md5 mismatch", md5: "00000000000000000000000000000000", size: info.Data.Size, wantErr: true},
+		{name: "size mismatch", md5: info.Data.Md5, size: info.Data.Size + 1, wantErr: true},
+		{name: "unchecked", md5: "", size: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileInfo := info
+			fileInfo.Data.Md5 = tt.md5
+			fileInfo.Data.Size = tt.size
+			body, err := client.DownloadFileVerified(context.Background(), fileInfo)
+			if err != nil {
+				t.Fatalf("DownloadFileVerified: %v", err)
+			}
+			got, readErr := io.ReadAll(body)
+			closeErr := body.Close()
+
+			if !tt.wantErr {
+				if readErr != nil || closeErr != nil {
+					t.Fatalf("got errors %v and %v on a valid download", readErr, closeErr)
+				}
+				if !bytes.Equal(got, data) {
+					t.Error("downloaded bytes differ from the uploaded ones")
+				}
+				return
+			}
+			var integrityErr *gofile.IntegrityError
+			if !errors.As(readErr, &integrityErr) || !errors.Is(readErr, gofile.ErrIntegrity) {
+				t.Fatalf("got read error %v, want an *IntegrityError", readErr)
+			}
+			if integrityErr.ContentId != uploaded.Data.Id {
+				t.Errorf("got content ID %q, want %q", integrityErr.ContentId, uploaded.Data.Id)
+			}
+			if !errors.Is(closeErr, gofile.ErrIntegrity) {
+				t.Errorf("got close error %v, want an *IntegrityError", closeErr)
+			}
+		})
+	}
+}
+
+func TestDownloadFileFromServersStopsOnClientError(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	data := testData(1000)
+	uploaded := uploadTestFile(t, client, srv, "data.bin", data)
+
+	body, server, err := client.DownloadFileFromServers(context.Background(), "store9", []string{gofiletest.StoreServer}, uploaded.Data.Id, "data.bin")
+	if err == nil {
+		defer body.Close()
+	}
+	// The fake answers 404 for servers other than StoreServer,
+	// which is not a server failure, so StoreServer is not tried.
+	if !errors.Is(err, gofile.ErrNotFound) {
+		t.Fatalf("got server %q and error %v, want ErrNotFound", server, err)
+	}
+}
+
+func TestDownloadFileFromServersFailsOver(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault gofiletest.Fault
+	}{
+		{name: "server error", fault: gofiletest.Fault{Kind: gofiletest.FaultServerError, StatusCode: http.StatusBadGateway}},
+		{name: "html page", fault: gofiletest.Fault{Kind: gofiletest.FaultHTMLPage}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(t)
+			recorder := &requestRecorder{}
+			client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()), gofile.WithRetryPolicy(gofile.NoRetry()))
+			data := testData(1000)
+			uploaded := uploadTestFile(t, client, srv, "data.bin", data)
+			tt.fault.PathPrefix = "/store0/"
+			srv.InjectFault(tt.fault)
+
+			body, server, err := client.DownloadFileFromServers(context.Background(), "store0", []string{gofiletest.StoreServer}, uploaded.Data.Id, "data.bin")
+			if err != nil {
+				t.Fatalf("DownloadFileFromServers: %v", err)
+			}
+			defer body.Close()
+			if server != gofiletest.StoreServer {
+				t.Errorf("served by %q, want %q", server, gofiletest.StoreServer)
+			}
+			got, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("reading download: %v", err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Error("downloaded bytes differ from the uploaded ones")
+			}
+			if got := recorder.count(http.MethodGet, "/store0/"); got != 1 {
+				t.Errorf("sent %d requests to the failing server, want 1", got)
+			}
+		})
+	}
+}
+
+func TestDownloadFileFromServersValidatesArguments(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+	ctx := context.Background()
+	servers := []string{"store0", gofiletest.StoreServer}
+
+	// Invalid arguments are not server failures, so no server is tried.
+	if _, _, err := client.DownloadFileFromServers(ctx, "", servers, "", "a.txt"); err == nil || strings.Contains(err.Error(), "servers failed") {
+		t.Errorf("got error %v without a file ID, want a validation error", err)
+	}
+	if _, _, err := client.DownloadFileFromServers(ctx, "", servers, "f1", ""); err == nil || strings.Contains(err.Error(), "servers failed") {
+		t.Errorf("got error %v without a file name, want a validation error", err)
+	}
+	if _, _, err := client.DownloadFileFromServers(ctx, "", nil, "f1", "a.txt"); err == nil {
+		t.Error("DownloadFileFromServers succeeded without servers")
+	}
+	if len(recorder.requests) != 0 {
+		t.Errorf("invalid arguments sent %d requests, want none", len(recorder.requests))
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 const (
@@ -22,7 +23,8 @@ func (c *GofileClient) CreateFolder(
 	ctx context.Context,
 	parentFolderId,
 	newFolderName string,
-) (CreateFolderResponseBody, error) {
+) (_ CreateFolderResponseBody, err error) {
+	defer c.observeOperation("CreateFolder", time.Now(), &err)
 
 	if parentFolderId == "" {
 		return CreateFolderResponseBody{}, fmt.Errorf("parentFolderId empty")
@@ -31,12 +33,9 @@ func (c *GofileClient) CreateFolder(
 		return CreateFolderResponseBody{}, fmt.Errorf("folder name empty")
 	}
 
-	var err error
-	if parentFolderId == rootFolderIdPlaceholderConst {
-		parentFolderId, err = c.rootFolderId(ctx)
-		if err != nil {
-			return CreateFolderResponseBody{}, err
-		}
+	parentFolderId, err = c.resolveFolderId(ctx, parentFolderId)
+	if err != nil {
+		return CreateFolderResponseBody{}, err
 	}
 
 	req, err := c.createPostFolderRequest(ctx, parentFolderId, newFolderName)
@@ -57,6 +56,58 @@ func (c *GofileClient) CreateFolder(
 	return ceateFolderResponseBody, nil
 }
 
+// GetFolderContents retrieves the metadata and the direct children
+// (files and subfolders) of the specified folder.
+//
+// The folderId may be a concrete folder identifier or the special value "root".
+// When "root" is provided, the client's root folder ID is resolved automatically.
+func (c *GofileClient) GetFolderContents(ctx context.Context, folderId string) (_ GetFolderContentsResponseBody, err error) {
+	defer c.observeOperation("GetFolderContents", time.Now(), &err)
+	if folderId == "" {
+		return GetFolderContentsResponseBody{}, fmt.Errorf("folderId empty")
+	}
+
+	folderId, err = c.resolveFolderId(ctx, folderId)
+	if err != nil {
+		return GetFolderContentsResponseBody{}, err
+	}
+
+	req, err := c.createGetFolderContentsRequest(ctx, folderId)
+	if err != nil {
+		return GetFolderContentsResponseBody{}, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return GetFolderContentsResponseBody{}, err
+	}
+	defer resp.Body.Close()
+
+	var getFolderContentsResponseBody GetFolderContentsResponseBody
+	err = json.NewDecoder(resp.Body).Decode(&getFolderContentsResponseBody)
+	if err != nil {
+		return GetFolderContentsResponseBody{}, err
+	}
+	return getFolderContentsResponseBody, nil
+}
+
+// resolveFolderId returns folderId unchanged unless it is the special value "root",
+// in which case the client's root folder ID is resolved.
+//
+// In guest mode "root" refers to the guest folder created by the first upload.
+func (c *GofileClient) resolveFolderId(ctx context.Context, folderId string) (string, error) {
+	if folderId != rootFolderIdPlaceholderConst {
+		return folderId, nil
+	}
+	if c.guest {
+		_, guestFolderId := c.guestSession()
+		if guestFolderId == "" {
+			return "", fmt.Errorf("guest folder is not created yet, upload a file first")
+		}
+		return guestFolderId, nil
+	}
+	return c.rootFolderId(ctx)
+}
+
 // createPostFolderRequest builds an HTTP POST request for creating a folder
 // under the specified parent folder.
 func (c *GofileClient) createPostFolderRequest(
@@ -81,7 +132,7 @@ func (c *GofileClient) createPostFolderRequest(
 		return nil, fmt.Errorf("creating marshalling response: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, postFolderEndpoint, bytes.NewReader(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.postFolderEndpoint(), bytes.NewReader(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("creating post folder request: %w", err)
 	}
@@ -0,0 +1,65 @@
+package gofile_test
+
+import (
+	"context"
+	"testing"
+
+	gofile "github.com/yaGatito/gofile-client"
+)
+
+func TestGetFolderContents(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	root := srv.RootFolderId(srv.Token())
+	uploadTestFile(t, client, srv, "b.txt", []byte("bb"))
+	uploadTestFile(t, client, srv, "a.txt", []byte("a"))
+	folderId := createTestFolder(t, client, root, "docs")
+
+	contents, err := client.GetFolderContents(context.Background(), "root")
+	if err != nil {
+		t.Fatalf("GetFolderContents: %v", err)
+	}
+	if contents.Data.Id != root {
+		t.Errorf("got folder %q, want the root folder %q", contents.Data.Id, root)
+	}
+	if len(contents.Data.Children) != 3 || contents.Data.ChildrenCount != 3 {
+		t.Errorf("got %d children, count %d, want 3", len(contents.Data.Children), contents.Data.ChildrenCount)
+	}
+
+	files := contents.Files()
+	if len(files) != 2 || files[0].Name != "a.txt" || files[1].Name != "b.txt" {
+		t.Fatalf("got files %+v, want a.txt and b.txt", files)
+	}
+	if files[0].Type != gofile.ContentTypeFile || files[0].Size != 1 || files[0].Md5 == "" {
+		t.Errorf("got file %+v, want a 1 byte file with its md5", files[0])
+	}
+	folders := contents.Folders()
+	if len(folders) != 1 || folders[0].Id != folderId || folders[0].Type != gofile.ContentTypeFolder {
+		t.Errorf("got folders %+v, want docs", folders)
+	}
+}
+
+func TestGetFolderContentsOfSubfolder(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	folderId := createTestFolder(t, client, srv.RootFolderId(srv.Token()), "docs")
+	uploadTestFileTo(t, client, folderId, "inner.txt", []byte("inner"))
+
+	contents, err := client.GetFolderContents(context.Background(), folderId)
+	if err != nil {
+		t.Fatalf("GetFolderContents: %v", err)
+	}
+	if contents.Data.Name != "docs" || len(contents.Files()) != 1 || len(contents.Folders()) != 0 {
+		t.Errorf("got folder %q with %d files and %d folders, want docs with 1 file",
+			contents.Data.Name, len(contents.Files()), len(contents.Folders()))
+	}
+}
+
+func TestGetFolderContentsRequiresFolderId(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+
+	if _, err := client.GetFolderContents(context.Background(), ""); err == nil {
+		t.Error("GetFolderContents succeeded without a folder ID")
+	}
+}
@@ -0,0 +1,114 @@
+package gofiletest
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FaultKind is the kind of failure injected by a Fault.
+type FaultKind int
+
+const (
+	// FaultHTMLPage answers with an HTML error page, as proxies and
+	// GoFile's maintenance pages do, with a 200 status unless StatusCode is set.
+	FaultHTMLPage FaultKind = iota
+	// FaultRateLimit answers with 429 and an "error-rateLimit" status,
+	// plus a Retry-After header when RetryAfter is set.
+	FaultRateLimit
+	// FaultPremiumRequired answers with an "error-notPremium" status,
+	// as GoFile does for premium-only endpoints.
+	FaultPremiumRequired
+	// FaultServerError answers with a plain text 500 response,
+	// or StatusCode when set.
+	FaultServerError
+)
+
+// Fault describes a failure injected into the responses of the server.
+type Fault struct {
+	Kind FaultKind
+	// Method restricts the fault to requests with this method. Empty matches every method.
+	Method string
+	// PathPrefix restricts the fault to requests whose path starts with it,
+	// e.g. "/contents" or "/uploadfile". Empty matches every path.
+	PathPrefix string
+	// Count is the number of matching requests the fault applies to.
+	// Values <= 0 apply it until ClearFaults is called.
+	Count int
+	// StatusCode overrides the HTTP status of the response when non-zero.
+	StatusCode int
+	// RetryAfter is sent in the Retry-After header of rate limit responses.
+	RetryAfter time.Duration
+}
+
+// InjectFault makes the server fail matching requests as described by f.
+// Faults are matched in the order they were injected.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// injectFault writes the response of the first fault matching r,
+// reporting whether one did.
+func (s *Server) injectFault(w http.ResponseWriter, r *http.Request) bool {
+	f := s.matchFault(r)
+	if f == nil {
+		return false
+	}
+
+	switch f.Kind {
+	case FaultHTMLPage:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(statusOr(f.StatusCode, http.StatusOK))
+		_, _ = w.Write([]byte("<!DOCTYPE html><html><head><title>GoFile</title></head><body>Service unavailable</body></html>"))
+	case FaultRateLimit:
+		if f.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(f.RetryAfter.Seconds()))))
+		}
+		writeError(w, statusOr(f.StatusCode, http.StatusTooManyRequests), "error-rateLimit")
+	case FaultPremiumRequired:
+		writeError(w, statusOr(f.StatusCode, http.StatusOK), "error-notPremium")
+	default:
+		http.Error(w, "internal server error", statusOr(f.StatusCode, http.StatusInternalServerError))
+	}
+	return true
+}
+
+// matchFault returns the first fault matching r, consuming one of its uses.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.PathPrefix) {
+			continue
+		}
+		if f.Count > 0 {
+			f.Count--
+			if f.Count == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func statusOr(status, fallback int) int {
+	if status != 0 {
+		return status
+	}
+	return fallback
+}
@@ -0,0 +1,716 @@
+// Package gofiletest provides an in-memory fake of the GoFile API
+// for testing code that uses the gofile client offline.
+//
+// The fake implements the account, contents, search, servers, upload
+// and download endpoints used by the client, and can inject faults
+// such as HTML error pages, rate limits and premium-only rejections.
+//
+// Usage example:
+//
+//	srv := gofiletest.NewServer()
+//	defer srv.Close()
+//	client, err := srv.NewClient()
+//	resp, err := client.UploadFile(ctx, "root", "file.txt", reader)
+package gofiletest
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gofile "github.com/yaGatito/gofile-client"
+)
+
+// StoreServer is the name of the store server holding every file.
+const StoreServer = "store1"
+
+// maxUploadMemory bounds the part of a multipart upload kept in memory
+// while parsing; larger files are spooled to temporary files by net/http.
+const maxUploadMemory = 32 << 20
+
+// Server is an in-memory fake GoFile server backed by an httptest.Server.
+//
+// Every account, file and folder lives in memory and is lost on Close.
+// A Server is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	accounts map[string]*account // by token
+	contents map[string]*content // by ID
+	faults   []*Fault
+	servers  []gofile.Server
+
+	token string
+}
+
+type account struct {
+	id           string
+	token        string
+	tier         string
+	email        string
+	rootFolderId string
+}
+
+type content struct {
+	id            string
+	contentType   string
+	name          string
+	owner         string
+	parentId      string
+	code          string
+	createTime    time.Time
+	data          []byte
+	md5           string
+	mimetype      string
+	downloadCount int64
+	childrenIds   []string
+
+	public      bool
+	description string
+	tags        string
+	expiry      int64
+	password    string
+}
+
+// NewServer starts a fake GoFile server with a standard account.
+// Its API key is returned by Token. The caller must call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		accounts: make(map[string]*account),
+		contents: make(map[string]*content),
+		servers:  []gofile.Server{{Name: StoreServer, Zone: "eu"}},
+	}
+	s.token = s.newAccount(gofile.TierStandard, "test@example.com").token
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Token returns the API key of the server's standard account.
+func (s *Server) Token() string {
+	return s.token
+}
+
+// AccountId returns the ID of the account identified by token,
+// or an empty string if there is none.
+func (s *Server) AccountId(token string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if acc, ok := s.accounts[token]; ok {
+		return acc.id
+	}
+	return ""
+}
+
+// RootFolderId returns the root folder ID of the account identified by token,
+// or an empty string if there is none.
+func (s *Server) RootFolderId(token string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if acc, ok := s.accounts[token]; ok {
+		return acc.rootFolderId
+	}
+	return ""
+}
+
+// SetTier changes the tier of the account identified by token,
+// e.g. to gofile.TierPremium.
+func (s *Server) SetTier(token, tier string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if acc, ok := s.accounts[token]; ok {
+		acc.tier = tier
+	}
+}
+
+// SetServers replaces the store servers listed by the servers endpoint,
+// StoreServer in the "eu" zone by default.
+//
+// Besides the default upload URL, every listed server accepts uploads
+// at /<name>/uploadfile, so that clients can be pointed at them with
+// the upload server URL template s.URL+"/%s/uploadfile".
+func (s *Server) SetServers(servers ...gofile.Server) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.servers = append([]gofile.Server(nil), servers...)
+}
+
+// isServer reports whether name is one of the listed store servers.
+func (s *Server) isServer(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, server := range s.servers {
+		if server.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// FileData returns a copy of the bytes of the file with the given ID.
+func (s *Server) FileData(id string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.contents[id]
+	if !ok || c.contentType != gofile.ContentTypeFile {
+		return nil, false
+	}
+	return bytes.Clone(c.data), true
+}
+
+// Options returns the client options pointing every endpoint at the server.
+func (s *Server) Options() []gofile.Option {
+	return []gofile.Option{
+		gofile.WithAPIBaseURL(s.URL),
+		gofile.WithUploadURL(s.URL + "/uploadfile"),
+		gofile.WithDownloadURLTemplate(s.URL + "/%s/download/web/%s/%s"),
+		gofile.WithHTTPClient(s.Client()),
+	}
+}
+
+// NewClient creates a client authenticated as the server's standard account.
+// The given options are applied after the ones returned by Options.
+func (s *Server) NewClient(opts ...gofile.Option) (*gofile.GofileClient, error) {
+	return gofile.NewWithOptions(s.token, append(s.Options(), opts...)...)
+}
+
+// NewGuestClient creates a guest client working against the server.
+// The given options are applied after the ones returned by Options.
+func (s *Server) NewGuestClient(opts ...gofile.Option) (*gofile.GofileClient, error) {
+	return gofile.NewGuestWithOptions(append(s.Options(), opts...)...)
+}
+
+// newAccount creates an account and its root folder. The caller must hold s.mu
+// unless the server is not started yet.
+func (s *Server) newAccount(tier, email string) *account {
+	acc := &account{
+		id:    newId(),
+		token: randomString(32),
+		tier:  tier,
+		email: email,
+	}
+	root := s.newContent(gofile.ContentTypeFolder, "root", acc.id, "")
+	acc.rootFolderId = root.id
+	s.accounts[acc.token] = acc
+	return acc
+}
+
+// newContent stores a new file or folder under parentId. The caller must hold s.mu.
+func (s *Server) newContent(contentType, name, owner, parentId string) *content {
+	c := &content{
+		id:          newId(),
+		contentType: contentType,
+		name:        name,
+		owner:       owner,
+		parentId:    parentId,
+		code:        randomString(6),
+		createTime:  time.Now(),
+	}
+	s.contents[c.id] = c
+	if parent, ok := s.contents[parentId]; ok {
+		parent.childrenIds = append(parent.childrenIds, c.id)
+	}
+	return c
+}
+
+// deleteContent removes a file or folder and all its descendants.
+// The caller must hold s.mu.
+func (s *Server) deleteContent(c *content) {
+	for _, childId := range c.childrenIds {
+		if child, ok := s.contents[childId]; ok {
+			s.deleteContent(child)
+		}
+	}
+	delete(s.contents, c.id)
+	if parent, ok := s.contents[c.parentId]; ok {
+		for i, id := range parent.childrenIds {
+			if id == c.id {
+				parent.childrenIds = append(parent.childrenIds[:i], parent.childrenIds[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.injectFault(w, r) {
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if r.Method == http.MethodPost && len(segments) == 1 && segments[0] == "uploadfile" ||
+		r.Method == http.MethodPost && len(segments) == 2 && segments[1] == "uploadfile" && s.isServer(segments[0]) {
+		// The upload body is read before locking the server.
+		s.handleUpload(w, r)
+		return
+	}
+	if r.Method == http.MethodGet && len(segments) == 5 && segments[1] == "download" && segments[2] == "web" {
+		// The file is streamed without holding the lock, so that a download
+		// read slowly does not block other requests.
+		s.handleDownload(w, r, segments[0], segments[3])
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && len(segments) == 1 && segments[0] == "servers":
+		s.handleServers(w, r)
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[0] == "accounts" && segments[1] == "getid":
+		s.handleGetId(w, r)
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[0] == "accounts":
+		s.handleGetAccount(w, r, segments[1])
+	case r.Method == http.MethodPost && len(segments) == 2 && segments[0] == "contents" && segments[1] == "createFolder":
+		s.handleCreateFolder(w, r)
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[0] == "contents" && segments[1] == "search":
+		s.handleSearch(w, r)
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[0] == "contents":
+		s.handleGetContent(w, r, segments[1])
+	case r.Method == http.MethodDelete && len(segments) == 1 && segments[0] == "contents":
+		s.handleDeleteContents(w, r)
+	case r.Method == http.MethodPut && len(segments) == 3 && segments[0] == "contents" && segments[2] == "update":
+		s.handleUpdateContent(w, r, segments[1])
+	default:
+		writeError(w, http.StatusNotFound, "error-notFound")
+	}
+}
+
+// authenticate returns the account of the request's bearer token,
+// writing an error response if there is none. The caller must hold s.mu.
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) (*account, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if acc, found := s.accounts[token]; ok && found {
+		return acc, true
+	}
+	writeError(w, http.StatusUnauthorized, "error-notAuthorized")
+	return nil, false
+}
+
+// lookup returns the content with the given ID owned by acc,
+// writing an error response if there is none. The caller must hold s.mu.
+func (s *Server) lookup(w http.ResponseWriter, acc *account, id string) (*content, bool) {
+	c, ok := s.contents[id]
+	if !ok || c.owner != acc.id {
+		writeError(w, http.StatusNotFound, "error-notFound")
+		return nil, false
+	}
+	return c, true
+}
+
+// handleServers lists the store servers of the requested zone,
+// along with the servers of every zone.
+func (s *Server) handleServers(w http.ResponseWriter, r *http.Request) {
+	zone := r.URL.Query().Get("zone")
+	servers := []gofile.Server{}
+	for _, server := range s.servers {
+		if zone == "" || server.Zone == zone {
+			servers = append(servers, server)
+		}
+	}
+	writeOk(w, map[string]any{
+		"servers":        servers,
+		"serversAllZone": s.servers,
+	})
+}
+
+func (s *Server) handleGetId(w http.ResponseWriter, r *http.Request) {
+	acc, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	writeOk(w, map[string]any{
+		"id":    acc.id,
+		"tier":  acc.tier,
+		"email": acc.email,
+	})
+}
+
+func (s *Server) handleGetAccount(w http.ResponseWriter, r *http.Request, accountId string) {
+	acc, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	if acc.id != accountId {
+		writeError(w, http.StatusUnauthorized, "error-notAuthorized")
+		return
+	}
+
+	var folderCount, fileCount int
+	var storage int64
+	for _, c := range s.contents {
+		if c.owner != acc.id || c.id == acc.rootFolderId {
+			continue
+		}
+		if c.contentType == gofile.ContentTypeFolder {
+			folderCount++
+		} else {
+			fileCount++
+			storage += int64(len(c.data))
+		}
+	}
+	writeOk(w, map[string]any{
+		"id":         acc.id,
+		"email":      acc.email,
+		"tier":       acc.tier,
+		"rootFolder": acc.rootFolderId,
+		"statsCurrent": map[string]any{
+			"folderCount": folderCount,
+			"fileCount":   fileCount,
+			"storage":     storage,
+		},
+	})
+}
+
+func (s *Server) handleCreateFolder(w http.ResponseWriter, r *http.Request) {
+	acc, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	var body struct {
+		ParentFolderId string `json:"parentFolderId"`
+		FolderName     string `json:"folderName"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "error-badRequest")
+		return
+	}
+	parent, ok := s.lookup(w, acc, body.ParentFolderId)
+	if !ok {
+		return
+	}
+	if parent.contentType != gofile.ContentTypeFolder {
+		writeError(w, http.StatusBadRequest, "error-notFolder")
+		return
+	}
+	name := body.FolderName
+	if name == "" {
+		name = randomString(8)
+	}
+
+	folder := s.newContent(gofile.ContentTypeFolder, name, acc.id, parent.id)
+	writeOk(w, map[string]any{
+		"id":           folder.id,
+		"owner":        folder.owner,
+		"name":         folder.name,
+		"parentFolder": folder.parentId,
+		"createTime":   folder.createTime.Unix(),
+		"code":         folder.code,
+	})
+}
+
+func (s *Server) handleGetContent(w http.ResponseWriter, r *http.Request, id string) {
+	acc, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	c, ok := s.lookup(w, acc, id)
+	if !ok {
+		return
+	}
+	if c.contentType == gofile.ContentTypeFile {
+		data := s.describe(c)
+		data["serverSelected"] = StoreServer
+		writeOk(w, data)
+		return
+	}
+
+	data := s.describe(c)
+	children := make(map[string]any, len(c.childrenIds))
+	var totalSize, totalDownloadCount int64
+	for _, childId := range c.childrenIds {
+		child := s.contents[childId]
+		children[childId] = s.describe(child)
+		totalSize += int64(len(child.data))
+		totalDownloadCount += child.downloadCount
+	}
+	data["public"] = c.public
+	data["children"] = children
+	data["totalSize"] = totalSize
+	data["totalDownloadCount"] = totalDownloadCount
+	writeOk(w, data)
+}
+
+// describe returns the JSON representation of a file or folder shared
+// by the contents endpoints. The caller must hold s.mu.
+func (s *Server) describe(c *content) map[string]any {
+	data := map[string]any{
+		"id":           c.id,
+		"type":         c.contentType,
+		"name":         c.name,
+		"parentFolder": c.parentId,
+		"code":         c.code,
+		"createTime":   c.createTime.Unix(),
+	}
+	if c.contentType == gofile.ContentTypeFolder {
+		data["childrenCount"] = len(c.childrenIds)
+		return data
+	}
+	data["size"] = len(c.data)
+	data["downloadCount"] = c.downloadCount
+	data["md5"] = c.md5
+	data["mimetype"] = c.mimetype
+	data["servers"] = []string{StoreServer}
+	data["link"] = s.downloadURL(c)
+	return data
+}
+
+func (s *Server) downloadURL(c *content) string {
+	return fmt.Sprintf("%s/%s/download/web/%s/%s", s.URL, StoreServer, c.id, c.name)
+}
+
+// handleSearch returns the files and folders under a folder tree
+// whose name contains the searched string, ignoring case.
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	acc, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	folder, ok := s.lookup(w, acc, r.URL.Query().Get("contentId"))
+	if !ok {
+		return
+	}
+	searched := strings.ToLower(r.URL.Query().Get("searchedString"))
+	if folder.contentType != gofile.ContentTypeFolder || searched == "" {
+		writeError(w, http.StatusBadRequest, "error-badRequest")
+		return
+	}
+
+	results := make(map[string]any)
+	var walk func(c *content)
+	walk = func(c *content) {
+		for _, childId := range c.childrenIds {
+			child := s.contents[childId]
+			if strings.Contains(strings.ToLower(child.name), searched) {
+				results[child.id] = s.describe(child)
+			}
+			walk(child)
+		}
+	}
+	walk(folder)
+	writeOk(w, results)
+}
+
+func (s *Server) handleDeleteContents(w http.ResponseWriter, r *http.Request) {
+	acc, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	var body struct {
+		ContentsId string `json:"contentsId"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.ContentsId == "" {
+		writeError(w, http.StatusBadRequest, "error-badRequest")
+		return
+	}
+
+	results := make(map[string]any)
+	for _, id := range strings.Split(body.ContentsId, ",") {
+		c, found := s.contents[id]
+		switch {
+		case !found || c.owner != acc.id:
+			results[id] = map[string]any{"status": "error-notFound", "data": map[string]any{}}
+		case c.id == acc.rootFolderId:
+			results[id] = map[string]any{"status": "error-notPermitted", "data": map[string]any{}}
+		default:
+			s.deleteContent(c)
+			results[id] = map[string]any{"status": "ok", "data": map[string]any{}}
+		}
+	}
+	writeOk(w, results)
+}
+
+func (s *Server) handleUpdateContent(w http.ResponseWriter, r *http.Request, id string) {
+	acc, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+	c, ok := s.lookup(w, acc, id)
+	if !ok {
+		return
+	}
+	var body struct {
+		Attribute      string `json:"attribute"`
+		AttributeValue any    `json:"attributeValue"`
+	}
+	// Numbers are kept as written, so that expiry timestamps
+	// are not formatted as floats below.
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "error-badRequest")
+		return
+	}
+	value := fmt.Sprint(body.AttributeValue)
+
+	if body.Attribute != "name" && c.contentType != gofile.ContentTypeFolder {
+		writeError(w, http.StatusBadRequest, "error-notFolder")
+		return
+	}
+	switch body.Attribute {
+	case "name":
+		c.name = value
+	case "description":
+		c.description = value
+	case "tags":
+		c.tags = value
+	case "public":
+		c.public = value == "true"
+	case "expiry":
+		expiry, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "error-badAttributeValue")
+			return
+		}
+		c.expiry = expiry
+	case "password":
+		c.password = value
+	default:
+		writeError(w, http.StatusBadRequest, "error-badAttribute")
+		return
+	}
+	writeOk(w, map[string]any{})
+}
+
+// handleUpload stores an uploaded file. Without a token and folder a new
+// guest account is created, whose token is returned as GoFile does.
+func (s *Server) handleUpload(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(maxUploadMemory); err != nil {
+		writeError(w, http.StatusBadRequest, "error-badRequest")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "error-noFile")
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "error-badRequest")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	folderId := r.FormValue("folderId")
+	var acc *account
+	var guestToken string
+	if r.Header.Get("Authorization") == "" && folderId == "" {
+		acc = s.newAccount(gofile.TierGuest, "")
+		guestToken = acc.token
+		folderId = acc.rootFolderId
+	} else {
+		var ok bool
+		if acc, ok = s.authenticate(w, r); !ok {
+			return
+		}
+		if folderId == "" {
+			folderId = acc.rootFolderId
+		}
+	}
+	folder, ok := s.lookup(w, acc, folderId)
+	if !ok {
+		return
+	}
+	if folder.contentType != gofile.ContentTypeFolder {
+		writeError(w, http.StatusBadRequest, "error-notFolder")
+		return
+	}
+
+	c := s.newContent(gofile.ContentTypeFile, header.Filename, acc.id, folder.id)
+	sum := md5.Sum(data)
+	c.data = data
+	c.md5 = hex.EncodeToString(sum[:])
+	c.mimetype = mime.TypeByExtension(path.Ext(c.name))
+	if c.mimetype == "" {
+		c.mimetype = http.DetectContentType(data)
+	}
+
+	writeOk(w, map[string]any{
+		"createTime":       c.createTime.Unix(),
+		"downloadPage":     s.URL + "/d/" + folder.code,
+		"guestToken":       guestToken,
+		"id":               c.id,
+		"md5":              c.md5,
+		"mimetype":         c.mimetype,
+		"name":             c.name,
+		"parentFolder":     folder.id,
+		"parentFolderCode": folder.code,
+		"servers":          []string{StoreServer},
+		"size":             len(c.data),
+		"type":             c.contentType,
+	})
+}
+
+// handleDownload serves the bytes of a file, with support for Range
+// and If-Range requests validated by ETag and Last-Modified.
+func (s *Server) handleDownload(w http.ResponseWriter, r *http.Request, server, id string) {
+	s.mu.Lock()
+	c, ok := s.contents[id]
+	if server != StoreServer || !ok || c.contentType != gofile.ContentTypeFile {
+		s.mu.Unlock()
+		http.NotFound(w, r)
+		return
+	}
+	c.downloadCount++
+	// File data is replaced on upload, never modified in place,
+	// so it can be served after unlocking.
+	data, sum, name, createTime := c.data, c.md5, c.name, c.createTime
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("ETag", strconv.Quote(sum))
+	http.ServeContent(w, r, name, createTime, bytes.NewReader(data))
+}
+
+// writeOk writes a successful GoFile JSON response holding data.
+func writeOk(w http.ResponseWriter, data any) {
+	writeJSON(w, http.StatusOK, map[string]any{"status": "ok", "data": data})
+}
+
+// writeError writes a GoFile JSON error response with the given status.
+func writeError(w http.ResponseWriter, httpStatus int, status string) {
+	writeJSON(w, httpStatus, map[string]any{"status": status, "data": map[string]any{}})
+}
+
+func writeJSON(w http.ResponseWriter, httpStatus int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(httpStatus)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// newId returns a random content or account ID in the UUID format used by GoFile.
+func newId() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	h := hex.EncodeToString(b)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+const alphanumeric = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// randomString returns a random alphanumeric string of length n.
+func randomString(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	for i := range b {
+		b[i] = alphanumeric[int(b[i])%len(alphanumeric)]
+	}
+	return string(b)
+}
@@ -0,0 +1,144 @@
+package gofiletest_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	gofile "github.com/yaGatito/gofile-client"
+	"github.com/yaGatito/gofile-client/gofiletest"
+)
+
+// quiet silences the client logs.
+var quiet = gofile.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+func newServerAndClient(t *testing.T, opts ...gofile.Option) (*gofiletest.Server, *gofile.GofileClient) {
+	t.Helper()
+	srv := gofiletest.NewServer()
+	t.Cleanup(srv.Close)
+	client, err := srv.NewClient(append([]gofile.Option{quiet}, opts...)...)
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	return srv, client
+}
+
+func TestServerUploadAndDownload(t *testing.T) {
+	srv, client := newServerAndClient(t)
+	ctx := context.Background()
+	data := []byte("hello, gofile")
+
+	uploaded, err := client.UploadFile(ctx, srv.RootFolderId(srv.Token()), "hello.txt", io.NopCloser(bytes.NewReader(data)))
+	if err != nil {
+		t.Fatalf("UploadFile: %v", err)
+	}
+	stored, ok := srv.FileData(uploaded.Data.Id)
+	if !ok || !bytes.Equal(stored, data) {
+		t.Fatalf("FileData = %q, %t, want %q", stored, ok, data)
+	}
+
+	body, err := client.DownloadFile(ctx, gofiletest.StoreServer, uploaded.Data.Id, "hello.txt")
+	if err != nil {
+		t.Fatalf("DownloadFile: %v", err)
+	}
+	defer body.Close()
+	got, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("reading download: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("downloaded %q, want %q", got, data)
+	}
+}
+
+func TestServerGuestUpload(t *testing.T) {
+	srv := gofiletest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewGuestClient(quiet)
+	if err != nil {
+		t.Fatalf("creating guest client: %v", err)
+	}
+
+	uploaded, err := client.UploadFile(context.Background(), "root", "a.txt", io.NopCloser(strings.NewReader("guest")))
+	if err != nil {
+		t.Fatalf("UploadFile: %v", err)
+	}
+	if uploaded.Data.GuestToken == "" {
+		t.Error("guest upload returned no guest token")
+	}
+	if srv.AccountId(uploaded.Data.GuestToken) == "" {
+		t.Error("guest token does not identify an account")
+	}
+}
+
+func TestServerDownloadDoesNotBlockOtherRequests(t *testing.T) {
+	srv, client := newServerAndClient(t)
+	ctx := context.Background()
+	data := bytes.Repeat([]byte("x"), 8<<20)
+
+	uploaded, err := client.UploadFile(ctx, srv.RootFolderId(srv.Token()), "big.bin", io.NopCloser(bytes.NewReader(data)))
+	if err != nil {
+		t.Fatalf("UploadFile: %v", err)
+	}
+
+	// The download body is left unread, so the server blocks while writing it.
+	body, err := client.DownloadFile(ctx, gofiletest.StoreServer, uploaded.Data.Id, "big.bin")
+	if err != nil {
+		t.Fatalf("DownloadFile: %v", err)
+	}
+	defer body.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	if _, err := client.GetFileInfo(ctx, "", uploaded.Data.Id); err != nil {
+		t.Fatalf("GetFileInfo during a pending download: %v", err)
+	}
+}
+
+func TestServerInjectFault(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault gofiletest.Fault
+		want  error
+	}{
+		{name: "html page", fault: gofiletest.Fault{Kind: gofiletest.FaultHTMLPage}, want: gofile.ErrHTMLResponse},
+		{name: "premium required", fault: gofiletest.Fault{Kind: gofiletest.FaultPremiumRequired}, want: gofile.ErrPremiumRequired},
+		{name: "not found status", fault: gofiletest.Fault{Kind: gofiletest.FaultServerError, StatusCode: http.StatusNotFound}, want: gofile.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client := newServerAndClient(t, gofile.WithRetryPolicy(gofile.NoRetry()))
+			tt.fault.Method = http.MethodGet
+			tt.fault.PathPrefix = "/accounts/getid"
+			srv.InjectFault(tt.fault)
+
+			_, err := client.GetAccount(context.Background())
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got error %v, want %v", err, tt.want)
+			}
+
+			srv.ClearFaults()
+			if _, err := client.GetAccount(context.Background()); err != nil {
+				t.Errorf("GetAccount after ClearFaults: %v", err)
+			}
+		})
+	}
+}
+
+func TestServerFaultCount(t *testing.T) {
+	srv, client := newServerAndClient(t, gofile.WithRetryPolicy(gofile.NoRetry()))
+	srv.InjectFault(gofiletest.Fault{Kind: gofiletest.FaultServerError, PathPrefix: "/accounts/getid", Count: 1})
+
+	if _, err := client.GetAccount(context.Background()); err == nil {
+		t.Fatal("first GetAccount succeeded, want the injected error")
+	}
+	if _, err := client.GetAccount(context.Background()); err != nil {
+		t.Fatalf("second GetAccount: %v", err)
+	}
+}
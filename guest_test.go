@@ -0,0 +1,105 @@
+package gofile_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+
+	gofile "github.com/yaGatito/gofile-client"
+	"github.com/yaGatito/gofile-client/gofiletest"
+)
+
+// newTestGuestClient creates a guest client of srv with no client-side
+// rate limit and silent logs. The given options are applied last.
+func newTestGuestClient(t *testing.T, srv *gofiletest.Server, opts ...gofile.Option) *gofile.GofileClient {
+	t.Helper()
+	defaults := []gofile.Option{
+		gofile.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		gofile.WithRateLimit(gofile.EndpointAPI, gofile.RateLimit{}),
+	}
+	client, err := srv.NewGuestClient(append(defaults, opts...)...)
+	if err != nil {
+		t.Fatalf("creating guest client: %v", err)
+	}
+	return client
+}
+
+func TestGuestUploadsShareFolder(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestGuestClient(t, srv)
+
+	first := uploadTestFileTo(t, client, "root", "a.txt", []byte("a"))
+	second := uploadTestFileTo(t, client, "root", "b.txt", []byte("b"))
+
+	token := first.Data.GuestToken
+	if token == "" || srv.AccountId(token) == "" {
+		t.Fatalf("first upload returned guest token %q, want a guest account token", token)
+	}
+	if second.Data.GuestToken != "" {
+		t.Errorf("second upload created guest account %q, want the captured one reused", second.Data.GuestToken)
+	}
+	if first.Data.ParentFolderId != srv.RootFolderId(token) || second.Data.ParentFolderId != first.Data.ParentFolderId {
+		t.Errorf("uploads landed in %q and %q, want the guest root folder %q",
+			first.Data.ParentFolderId, second.Data.ParentFolderId, srv.RootFolderId(token))
+	}
+
+	// Reading the folder requires the captured guest token.
+	contents, err := client.GetFolderContents(context.Background(), "root")
+	if err != nil {
+		t.Fatalf("GetFolderContents: %v", err)
+	}
+	if len(contents.Files()) != 2 {
+		t.Errorf("guest folder holds %d files, want 2", len(contents.Files()))
+	}
+}
+
+func TestGuestConcurrentFirstUploadsCreateOneAccount(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestGuestClient(t, srv)
+
+	const uploads = 8
+	results := make([]gofile.UploadFileResponseBody, uploads)
+	errs := make([]error, uploads)
+	var wg sync.WaitGroup
+	for i := range uploads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data := []byte(fmt.Sprintf("file %d", i))
+			results[i], errs[i] = client.UploadFile(context.Background(), "root", fmt.Sprintf("%d.txt", i), io.NopCloser(bytes.NewReader(data)))
+		}()
+	}
+	wg.Wait()
+
+	var tokens []string
+	for i := range uploads {
+		if errs[i] != nil {
+			t.Fatalf("upload %d: %v", i, errs[i])
+		}
+		if results[i].Data.GuestToken != "" {
+			tokens = append(tokens, results[i].Data.GuestToken)
+		}
+		if results[i].Data.ParentFolderId != results[0].Data.ParentFolderId {
+			t.Errorf("upload %d landed in %q, want %q", i, results[i].Data.ParentFolderId, results[0].Data.ParentFolderId)
+		}
+	}
+	if len(tokens) != 1 {
+		t.Fatalf("concurrent uploads created %d guest accounts, want 1", len(tokens))
+	}
+	if results[0].Data.ParentFolderId != srv.RootFolderId(tokens[0]) {
+		t.Errorf("uploads landed in %q, want the guest root folder %q", results[0].Data.ParentFolderId, srv.RootFolderId(tokens[0]))
+	}
+}
+
+func TestGuestRootBeforeFirstUpload(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestGuestClient(t, srv)
+
+	if _, err := client.GetFolderContents(context.Background(), "root"); err == nil {
+		t.Error("GetFolderContents of the guest root succeeded before any upload")
+	}
+}
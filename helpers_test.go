@@ -0,0 +1,194 @@
+package gofile_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gofile "github.com/yaGatito/gofile-client"
+	"github.com/yaGatito/gofile-client/gofiletest"
+)
+
+// fastRetries retries quickly enough for tests.
+var fastRetries = gofile.RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     time.Millisecond,
+}
+
+// newTestServer starts a fake GoFile server closed at the end of the test.
+func newTestServer(t *testing.T) *gofiletest.Server {
+	t.Helper()
+	srv := gofiletest.NewServer()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newTestClient creates a client of srv with fast retries, no client-side
+// rate limit and silent logs. The given options are applied last.
+func newTestClient(t *testing.T, srv *gofiletest.Server, opts ...gofile.Option) *gofile.GofileClient {
+	t.Helper()
+	defaults := []gofile.Option{
+		gofile.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		gofile.WithRetryPolicy(fastRetries),
+		gofile.WithRateLimit(gofile.EndpointAPI, gofile.RateLimit{}),
+	}
+	client, err := srv.NewClient(append(defaults, opts...)...)
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	return client
+}
+
+// uploadTestFile uploads data into the root folder of the server's account.
+func uploadTestFile(t *testing.T, client *gofile.GofileClient, srv *gofiletest.Server, name string, data []byte) gofile.UploadFileResponseBody {
+	t.Helper()
+	return uploadTestFileTo(t, client, srv.RootFolderId(srv.Token()), name, data)
+}
+
+// uploadTestFileTo uploads data into the given folder.
+func uploadTestFileTo(t *testing.T, client *gofile.GofileClient, folderId, name string, data []byte) gofile.UploadFileResponseBody {
+	t.Helper()
+	resp, err := client.UploadFile(context.Background(), folderId, name, io.NopCloser(bytes.NewReader(data)))
+	if err != nil {
+		t.Fatalf("uploading %s: %v", name, err)
+	}
+	return resp
+}
+
+// createTestFolder creates a folder under parentFolderId.
+func createTestFolder(t *testing.T, client *gofile.GofileClient, parentFolderId, name string) string {
+	t.Helper()
+	resp, err := client.CreateFolder(context.Background(), parentFolderId, name)
+	if err != nil {
+		t.Fatalf("creating folder %s: %v", name, err)
+	}
+	return resp.Data.Id
+}
+
+// testData returns n bytes of deterministic content.
+func testData(n int) []byte {
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	return data
+}
+
+// recordedRequest is a request sent by a client, with its body
+// when the body can be replayed.
+type recordedRequest struct {
+	method string
+	path   string
+	body   string
+}
+
+// requestRecorder records the requests sent by a client. Requests matching
+// a stub are answered with the stubbed JSON body instead of reaching the server.
+type requestRecorder struct {
+	mu       sync.Mutex
+	requests []recordedRequest
+	stubs    map[string]string // by method and path, e.g. "POST /contents/copy"
+}
+
+// stub answers every request with the given method and path with body.
+func (r *requestRecorder) stub(method, path, body string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stubs == nil {
+		r.stubs = make(map[string]string)
+	}
+	r.stubs[method+" "+path] = body
+}
+
+func (r *requestRecorder) middleware() gofile.Middleware {
+	return func(next gofile.Doer) gofile.Doer {
+		return gofile.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			recorded := recordedRequest{method: req.Method, path: req.URL.Path}
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				data, _ := io.ReadAll(body)
+				recorded.body = string(data)
+			}
+
+			r.mu.Lock()
+			r.requests = append(r.requests, recorded)
+			stub, ok := r.stubs[req.Method+" "+req.URL.Path]
+			r.mu.Unlock()
+
+			if !ok {
+				return next.Do(req)
+			}
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(stub)),
+				Request:    req,
+			}, nil
+		})
+	}
+}
+
+// find returns the last recorded request with the given method and path.
+func (r *requestRecorder) find(method, path string) (recordedRequest, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := len(r.requests) - 1; i >= 0; i-- {
+		if r.requests[i].method == method && r.requests[i].path == path {
+			return r.requests[i], true
+		}
+	}
+	return recordedRequest{}, false
+}
+
+// count returns the number of recorded requests with the given method
+// whose path starts with prefix.
+func (r *requestRecorder) count(method, prefix string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, req := range r.requests {
+		if req.method == method && strings.HasPrefix(req.path, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+// closeRecorder is a ReadCloser recording whether it was closed.
+type closeRecorder struct {
+	io.Reader
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newCloseRecorder(r io.Reader) *closeRecorder {
+	return &closeRecorder{Reader: r, closed: make(chan struct{})}
+}
+
+func (r *closeRecorder) Close() error {
+	r.once.Do(func() { close(r.closed) })
+	return nil
+}
+
+// waitClosed fails the test unless r is closed within a second.
+func (r *closeRecorder) waitClosed(t *testing.T) {
+	t.Helper()
+	select {
+	case <-r.closed:
+	case <-time.After(time.Second):
+		t.Fatal("reader was not closed")
+	}
+}
@@ -0,0 +1,125 @@
+package gofile
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+)
+
+// ErrIntegrity is matched through errors.Is by every IntegrityError.
+var ErrIntegrity = errors.New("gofile: integrity check failed")
+
+// IntegrityError is returned when the MD5 or size of transferred bytes
+// does not match the value expected for the file.
+type IntegrityError struct {
+	// ContentId is the ID of the file the check was made for.
+	ContentId string
+	// ExpectedMd5 and ActualMd5 are hex encoded MD5 sums.
+	ExpectedMd5 string
+	ActualMd5   string
+	// ExpectedSize and ActualSize are sizes in bytes.
+	ExpectedSize int64
+	ActualSize   int64
+	// Deleted is set when the corrupted remote copy of an upload was deleted.
+	Deleted bool
+}
+
+func (e *IntegrityError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "gofile: integrity check of %s failed", e.ContentId)
+	if e.ExpectedSize > 0 && e.ExpectedSize != e.ActualSize {
+		fmt.Fprintf(&sb, ": size %d, expected %d", e.ActualSize, e.ExpectedSize)
+	} else {
+		fmt.Fprintf(&sb, ": md5 %s, expected %s", e.ActualMd5, e.ExpectedMd5)
+	}
+	if e.Deleted {
+		sb.WriteString(" (remote copy deleted)")
+	}
+	return sb.String()
+}
+
+// Is reports whether target is ErrIntegrity.
+func (e *IntegrityError) Is(target error) bool {
+	return target == ErrIntegrity
+}
+
+// md5Counter computes the MD5 sum and size of the bytes written to it.
+type md5Counter struct {
+	hash hash.Hash
+	size int64
+}
+
+func newMd5Counter() *md5Counter {
+	return &md5Counter{hash: md5.New()}
+}
+
+func (m *md5Counter) Write(p []byte) (int, error) {
+	m.size += int64(len(p))
+	return m.hash.Write(p)
+}
+
+// sum returns the hex encoded MD5 sum of the bytes written so far.
+func (m *md5Counter) sum() string {
+	return hex.EncodeToString(m.hash.Sum(nil))
+}
+
+// verifyingReader hashes the bytes read through it and checks their size
+// and MD5 sum against the expected values at EOF.
+type verifyingReader struct {
+	io.ReadCloser
+	contentId    string
+	expectedMd5  string
+	expectedSize int64
+	counter      *md5Counter
+	verified     bool
+	err          error
+}
+
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.ReadCloser.Read(p)
+	r.counter.Write(p[:n])
+	if err == io.EOF {
+		if verifyErr := r.verify(); verifyErr != nil {
+			return n, verifyErr
+		}
+	}
+	return n, err
+}
+
+// Close closes the underlying reader. If the whole file was read but not
+// yet verified, or the verification failed, it returns the integrity error.
+func (r *verifyingReader) Close() error {
+	closeErr := r.ReadCloser.Close()
+	if !r.verified && r.expectedSize > 0 && r.counter.size == r.expectedSize {
+		r.verify()
+	}
+	if r.err != nil {
+		return r.err
+	}
+	return closeErr
+}
+
+// verify compares the bytes read so far with the expected size and MD5 sum.
+func (r *verifyingReader) verify() error {
+	r.verified = true
+	actualMd5 := r.counter.sum()
+	sizeMismatch := r.expectedSize > 0 && r.counter.size != r.expectedSize
+	md5Mismatch := r.expectedMd5 != "" && !strings.EqualFold(r.expectedMd5, actualMd5)
+	if sizeMismatch || md5Mismatch {
+		r.err = &IntegrityError{
+			ContentId:    r.contentId,
+			ExpectedMd5:  r.expectedMd5,
+			ActualMd5:    actualMd5,
+			ExpectedSize: r.expectedSize,
+			ActualSize:   r.counter.size,
+		}
+	}
+	return r.err
+}
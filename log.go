@@ -0,0 +1,133 @@
+package gofile
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// redacted replaces secrets in log records.
+const redacted = "[REDACTED]"
+
+// bearerPattern matches bearer credentials embedded in logged text.
+var bearerPattern = regexp.MustCompile(`(?i)bearer\s+[^\s"',;]+`)
+
+// sensitiveLogKeys are attribute keys whose values are never logged.
+var sensitiveLogKeys = map[string]bool{
+	"authorization":   true,
+	"token":           true,
+	"apikey":          true,
+	"api_key":         true,
+	"guesttoken":      true,
+	"guest_token":     true,
+	"websitetoken":    true,
+	"website_token":   true,
+	"x-website-token": true,
+	"password":        true,
+}
+
+// defaultLogger returns the logger used unless configured otherwise:
+// text output to stdout at the Info level, so per-request detail
+// logged at the Debug level is hidden.
+func defaultLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+}
+
+// secrets tracks the values that must never appear in logs:
+// the API key or guest token of the client. Website tokens are only sent
+// in a header that is never logged, and are redacted by attribute key.
+type secrets struct {
+	values sync.Map
+}
+
+func (s *secrets) add(value string) {
+	if value != "" {
+		s.values.Store(value, struct{}{})
+	}
+}
+
+func (s *secrets) remove(value string) {
+	s.values.Delete(value)
+}
+
+func (s *secrets) redact(text string) string {
+	s.values.Range(func(key, _ any) bool {
+		text = strings.ReplaceAll(text, key.(string), redacted)
+		return true
+	})
+	return bearerPattern.ReplaceAllString(text, "Bearer "+redacted)
+}
+
+// redactingHandler removes secrets from the message and attributes of every
+// record before passing it to the wrapped handler.
+type redactingHandler struct {
+	handler slog.Handler
+	secrets *secrets
+}
+
+// newRedactingLogger wraps the handler of logger so that secrets are redacted.
+func newRedactingLogger(logger *slog.Logger, secrets *secrets) *slog.Logger {
+	return slog.New(&redactingHandler{handler: logger.Handler(), secrets: secrets})
+}
+
+func (h *redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *redactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	record := slog.NewRecord(r.Time, r.Level, h.secrets.redact(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		record.AddAttrs(h.redactAttr(a))
+		return true
+	})
+	return h.handler.Handle(ctx, record)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redactedAttrs := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redactedAttrs[i] = h.redactAttr(a)
+	}
+	return &redactingHandler{handler: h.handler.WithAttrs(redactedAttrs), secrets: h.secrets}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{handler: h.handler.WithGroup(name), secrets: h.secrets}
+}
+
+func (h *redactingHandler) redactAttr(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	if sensitiveLogKeys[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, redacted)
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, h.secrets.redact(a.Value.String()))
+	case slog.KindGroup:
+		group := a.Value.Group()
+		redactedGroup := make([]any, len(group))
+		for i, ga := range group {
+			redactedGroup[i] = h.redactAttr(ga)
+		}
+		return slog.Group(a.Key, redactedGroup...)
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.String(a.Key, h.secrets.redact(err.Error()))
+		}
+		return slog.String(a.Key, h.secrets.redact(a.Value.String()))
+	}
+	return a
+}
+
+// endpointOf returns the URL without its query string and user info,
+// which may carry credentials.
+func endpointOf(u *url.URL) string {
+	endpoint := *u
+	endpoint.RawQuery = ""
+	endpoint.User = nil
+	return endpoint.String()
+}
@@ -0,0 +1,43 @@
+package gofile_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	gofile "github.com/yaGatito/gofile-client"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestLogsRedactToken(t *testing.T) {
+	srv := newTestServer(t)
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	// The transport echoes the API key in its errors, which are logged on retries.
+	leaky := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("rejected %s with key %s", req.Header.Get("Authorization"), srv.Token())
+	})}
+	client := newTestClient(t, srv, gofile.WithLogger(logger), gofile.WithHTTPClient(leaky))
+
+	if _, err := client.GetAccount(context.Background()); err == nil {
+		t.Fatal("GetAccount succeeded through a failing transport")
+	}
+	if !strings.Contains(logs.String(), "Request failed, retrying") {
+		t.Fatalf("retries were not logged:\n%s", logs.String())
+	}
+	if strings.Contains(logs.String(), srv.Token()) {
+		t.Errorf("API key found in logs:\n%s", logs.String())
+	}
+	if !strings.Contains(logs.String(), "[REDACTED]") {
+		t.Errorf("no redaction marker in logs:\n%s", logs.String())
+	}
+}
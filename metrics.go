@@ -0,0 +1,224 @@
+package gofile
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MetricsCollector receives operation-level metrics of the client.
+//
+// Implementations must be safe for concurrent use and should not block,
+// since they are called on the request path.
+type MetricsCollector interface {
+	// ObserveOperation is called once a public operation of the client returns.
+	// Operations returning a body, such as DownloadFile, are reported once the body
+	// is read to EOF, fails or is closed, so that the duration and the status cover
+	// the whole transfer, including integrity failures detected while reading.
+	//
+	// The operation is the method name, e.g. "UploadFile", "DownloadFile",
+	// "CreateFolder" or "GetFileInfo". The status is "ok" on success and,
+	// on failure, the GoFile status of an *APIError (e.g. "error-notFound"),
+	// "http-<code>" for API errors without one, or one of "error-html",
+	// "error-integrity", "canceled" and "error".
+	//
+	// Operations built on top of others, such as DownloadFileVerified,
+	// also report the operations they call.
+	ObserveOperation(operation, status string, duration time.Duration)
+	// AddTransferredBytes is called as upload request bodies are sent and
+	// download response bodies are received, including multipart framing and retries.
+	// The direction is OperationUpload or OperationDownload.
+	AddTransferredBytes(direction string, n int64)
+}
+
+// noopMetrics is the collector used unless configured otherwise.
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveOperation(string, string, time.Duration) {}
+func (noopMetrics) AddTransferredBytes(string, int64)              {}
+
+// observeOperation reports an operation started at start to the metrics collector.
+// It is meant to be deferred with a pointer to the named error result of the operation.
+func (c *GofileClient) observeOperation(operation string, start time.Time, err *error) {
+	c.metrics.ObserveOperation(operation, operationStatus(*err), time.Since(start))
+}
+
+// observeStream reports an operation returning a body. A failed operation is
+// reported at once; otherwise *body is wrapped so that the operation is reported
+// once the body is read to EOF, fails or is closed. It is meant to be deferred
+// with pointers to the named results of the operation.
+func (c *GofileClient) observeStream(operation string, start time.Time, body *io.ReadCloser, err *error) {
+	if *err != nil {
+		c.observeOperation(operation, start, err)
+		return
+	}
+	*body = &observedReadCloser{ReadCloser: *body, observe: func(err error) {
+		c.observeOperation(operation, start, &err)
+	}}
+}
+
+// observedReadCloser calls observe once, with the first read error other
+// than io.EOF, or with the result of Close.
+type observedReadCloser struct {
+	io.ReadCloser
+	observe func(err error)
+	once    sync.Once
+}
+
+func (r *observedReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if err != nil {
+		observed := err
+		if err == io.EOF {
+			observed = nil
+		}
+		r.once.Do(func() { r.observe(observed) })
+	}
+	return n, err
+}
+
+func (r *observedReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(func() { r.observe(err) })
+	return err
+}
+
+// operationStatus classifies the error returned by an operation.
+func operationStatus(err error) string {
+	if err == nil {
+		return statusOk
+	}
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &apiErr):
+		if apiErr.Status != "" {
+			return apiErr.Status
+		}
+		if apiErr.html {
+			return "error-html"
+		}
+		return "http-" + strconv.Itoa(apiErr.HTTPStatus)
+	case errors.Is(err, ErrIntegrity):
+		return "error-integrity"
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "canceled"
+	}
+	return "error"
+}
+
+// transferMetricsMiddleware counts the bytes of upload request bodies
+// and download response bodies.
+func transferMetricsMiddleware(collector MetricsCollector) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			class := endpointClassOf(req)
+			if class == EndpointUpload && req.Body != nil && req.Body != http.NoBody {
+				req.Body = &countingReadCloser{ReadCloser: req.Body, direction: OperationUpload, collector: collector}
+			}
+			resp, err := next.Do(req)
+			if err == nil && class == EndpointDownload {
+				resp.Body = &countingReadCloser{ReadCloser: resp.Body, direction: OperationDownload, collector: collector}
+			}
+			return resp, err
+		})
+	}
+}
+
+// countingReadCloser reports the bytes read through it to a collector.
+type countingReadCloser struct {
+	io.ReadCloser
+	direction string
+	collector MetricsCollector
+}
+
+func (r *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.collector.AddTransferredBytes(r.direction, int64(n))
+	}
+	return n, err
+}
+
+// defaultLatencyBuckets are the upper bounds, in seconds, of the latency
+// histograms of ExpvarMetrics.
+var defaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
+
+// ExpvarMetrics is a MetricsCollector publishing the client metrics with expvar.
+//
+// The published map holds:
+//   - "operations": the number of calls per operation
+//   - "errors": per operation, the number of failed calls per status
+//   - "latency_seconds": per operation, a cumulative histogram with a "le_<bound>"
+//     count per bucket, plus "le_inf", "count" and "sum"
+//   - "bytes": the number of bytes transferred per direction
+type ExpvarMetrics struct {
+	operations *expvar.Map
+	errors     *expvar.Map
+	latency    *expvar.Map
+	bytes      *expvar.Map
+
+	mu sync.Mutex
+}
+
+var _ MetricsCollector = &ExpvarMetrics{}
+
+// NewExpvarMetrics creates an ExpvarMetrics published under the given name.
+// Like expvar.Publish, it panics if the name is already in use.
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	m := &ExpvarMetrics{
+		operations: new(expvar.Map).Init(),
+		errors:     new(expvar.Map).Init(),
+		latency:    new(expvar.Map).Init(),
+		bytes:      new(expvar.Map).Init(),
+	}
+	root := expvar.NewMap(name)
+	root.Set("operations", m.operations)
+	root.Set("errors", m.errors)
+	root.Set("latency_seconds", m.latency)
+	root.Set("bytes", m.bytes)
+	return m
+}
+
+// ObserveOperation implements MetricsCollector.
+func (m *ExpvarMetrics) ObserveOperation(operation, status string, duration time.Duration) {
+	m.operations.Add(operation, 1)
+	if status != statusOk {
+		m.subMap(m.errors, operation).Add(status, 1)
+	}
+
+	histogram := m.subMap(m.latency, operation)
+	seconds := duration.Seconds()
+	for _, bound := range defaultLatencyBuckets {
+		if seconds <= bound {
+			histogram.Add("le_"+strconv.FormatFloat(bound, 'g', -1, 64), 1)
+		}
+	}
+	histogram.Add("le_inf", 1)
+	histogram.Add("count", 1)
+	histogram.AddFloat("sum", seconds)
+}
+
+// AddTransferredBytes implements MetricsCollector.
+func (m *ExpvarMetrics) AddTransferredBytes(direction string, n int64) {
+	m.bytes.Add(direction, n)
+}
+
+// subMap returns the map stored under key in parent, creating it if needed.
+func (m *ExpvarMetrics) subMap(parent *expvar.Map, key string) *expvar.Map {
+	if sub, ok := parent.Get(key).(*expvar.Map); ok {
+		return sub
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if sub, ok := parent.Get(key).(*expvar.Map); ok {
+		return sub
+	}
+	sub := new(expvar.Map).Init()
+	parent.Set(key, sub)
+	return sub
+}
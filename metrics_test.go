@@ -0,0 +1,112 @@
+package gofile_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	gofile "github.com/yaGatito/gofile-client"
+	"github.com/yaGatito/gofile-client/gofiletest"
+)
+
+// recordingMetrics records the operations and bytes reported by a client.
+type recordingMetrics struct {
+	mu         sync.Mutex
+	operations []string
+	bytes      map[string]int64
+}
+
+func (m *recordingMetrics) ObserveOperation(operation, status string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.operations = append(m.operations, operation+" "+status)
+}
+
+func (m *recordingMetrics) AddTransferredBytes(direction string, n int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.bytes == nil {
+		m.bytes = make(map[string]int64)
+	}
+	m.bytes[direction] += n
+}
+
+// has reports whether operation was reported with status.
+func (m *recordingMetrics) has(operation, status string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, observed := range m.operations {
+		if observed == operation+" "+status {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMetricsReportDownloadOnceConsumed(t *testing.T) {
+	srv := newTestServer(t)
+	metrics := &recordingMetrics{}
+	client := newTestClient(t, srv, gofile.WithMetrics(metrics))
+	data := testData(10 << 10)
+	uploaded := uploadTestFile(t, client, srv, "data.bin", data)
+
+	body, err := client.DownloadFile(context.Background(), gofiletest.StoreServer, uploaded.Data.Id, "data.bin")
+	if err != nil {
+		t.Fatalf("DownloadFile: %v", err)
+	}
+	if metrics.has("DownloadFile", "ok") {
+		t.Error("DownloadFile reported before its body was read")
+	}
+	if _, err := io.Copy(io.Discard, body); err != nil {
+		t.Fatalf("reading download: %v", err)
+	}
+	body.Close()
+
+	if !metrics.has("UploadFile", "ok") || !metrics.has("DownloadFile", "ok") {
+		t.Errorf("got operations %q, want UploadFile and DownloadFile", metrics.operations)
+	}
+	if got := metrics.bytes[gofile.OperationDownload]; got != int64(len(data)) {
+		t.Errorf("counted %d downloaded bytes, want %d", got, len(data))
+	}
+	if got := metrics.bytes[gofile.OperationUpload]; got < int64(len(data)) {
+		t.Errorf("counted %d uploaded bytes, want at least %d", got, len(data))
+	}
+}
+
+func TestMetricsReportDownloadIntegrityFailure(t *testing.T) {
+	srv := newTestServer(t)
+	metrics := &recordingMetrics{}
+	client := newTestClient(t, srv, gofile.WithMetrics(metrics))
+	uploaded := uploadTestFile(t, client, srv, "data.bin", testData(1000))
+	info, err := client.GetFileInfo(context.Background(), "", uploaded.Data.Id)
+	if err != nil {
+		t.Fatalf("GetFileInfo: %v", err)
+	}
+
+	info.Data.Md5 = "00000000000000000000000000000000"
+	body, err := client.DownloadFileVerified(context.Background(), info)
+	if err != nil {
+		t.Fatalf("DownloadFileVerified: %v", err)
+	}
+	_, _ = io.Copy(io.Discard, body)
+	body.Close()
+
+	if !metrics.has("DownloadFileVerified", "error-integrity") {
+		t.Errorf("got operations %q, want a DownloadFileVerified integrity error", metrics.operations)
+	}
+}
+
+func TestMetricsReportAPIErrorStatus(t *testing.T) {
+	srv := newTestServer(t)
+	metrics := &recordingMetrics{}
+	client := newTestClient(t, srv, gofile.WithMetrics(metrics))
+
+	if _, err := client.GetFileInfo(context.Background(), "", "missing"); err == nil {
+		t.Fatal("GetFileInfo succeeded for a missing file")
+	}
+	if !metrics.has("GetFileInfo", "error-notFound") {
+		t.Errorf("got operations %q, want a GetFileInfo error-notFound", metrics.operations)
+	}
+}
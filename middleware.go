@@ -0,0 +1,184 @@
+package gofile
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// requestIdHeader carries the request ID to GoFile.
+const requestIdHeader = "X-Request-Id"
+
+// Doer sends a single HTTP request. *http.Client implements Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to the Doer interface.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer that sends every request of the client.
+//
+// Middlewares run once per attempt, after the rate limiter and before the
+// response is validated, so a middleware may also return a response of its own,
+// for example to inject faults. The response body must be left for the client to read.
+//
+// A middleware that returns without calling next must close req.Body, as the
+// transport would have: upload bodies are streamed by a goroutine that only
+// stops, and closes the uploaded reader, once the body is closed.
+type Middleware func(next Doer) Doer
+
+// chainMiddleware wraps doer with middlewares, the first one being the outermost.
+func chainMiddleware(doer Doer, middlewares []Middleware) Doer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+	return doer
+}
+
+// HookMiddleware returns a Middleware calling before ahead of every send
+// and after once the response or the error is known.
+//
+// A non-nil error returned by before aborts the request with that error
+// and closes its body. Either hook may be nil.
+func HookMiddleware(
+	before func(req *http.Request) error,
+	after func(req *http.Request, resp *http.Response, err error),
+) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if before != nil {
+				if err := before(req); err != nil {
+					closeRequestBody(req)
+					return nil, err
+				}
+			}
+			resp, err := next.Do(req)
+			if after != nil {
+				after(req, resp, err)
+			}
+			return resp, err
+		})
+	}
+}
+
+// LoggingMiddleware returns a Middleware logging every request and its response
+// at the Debug level, with the method, endpoint, status, duration, bytes and request ID.
+//
+// Headers and query strings are never logged. The client installs a logging
+// middleware using its own logger by default.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("endpoint", endpointOf(req.URL)),
+				slog.String("request_id", requestIdFrom(ctx)),
+			}
+			logger.LogAttrs(ctx, slog.LevelDebug, "Sending request", append(attrs, slog.Int64("bytes", req.ContentLength))...)
+
+			start := time.Now()
+			resp, err := next.Do(req)
+			if err != nil {
+				logger.LogAttrs(ctx, slog.LevelDebug, "Request failed",
+					append(attrs, slog.Duration("duration", time.Since(start)), slog.Any("error", err))...)
+				return nil, err
+			}
+			logger.LogAttrs(ctx, slog.LevelDebug, "Received response", append(attrs,
+				slog.Int("status", resp.StatusCode),
+				slog.Duration("duration", time.Since(start)),
+				slog.Int64("bytes", resp.ContentLength),
+			)...)
+			return resp, nil
+		})
+	}
+}
+
+// RequestMetrics describes a single request sent by the client.
+type RequestMetrics struct {
+	Method    string
+	Endpoint  string
+	Class     EndpointClass
+	RequestId string
+	// StatusCode is zero when the request failed at the transport level.
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// MetricsMiddleware returns a Middleware reporting every request to fn
+// once its response headers are received or it failed.
+func MetricsMiddleware(fn func(RequestMetrics)) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+			metrics := RequestMetrics{
+				Method:    req.Method,
+				Endpoint:  endpointOf(req.URL),
+				Class:     endpointClassOf(req),
+				RequestId: requestIdFrom(req.Context()),
+				Duration:  time.Since(start),
+				Err:       err,
+			}
+			if resp != nil {
+				metrics.StatusCode = resp.StatusCode
+			}
+			fn(metrics)
+			return resp, err
+		})
+	}
+}
+
+// RequestIdMiddleware returns a Middleware sending the request ID of the
+// request context in the X-Request-Id header.
+// A new ID is generated for requests whose context carries none.
+// The client installs it by default.
+func RequestIdMiddleware() Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if requestIdFrom(req.Context()) == "" {
+				req = req.WithContext(withRequestId(req.Context()))
+			}
+			req.Header.Set(requestIdHeader, requestIdFrom(req.Context()))
+			return next.Do(req)
+		})
+	}
+}
+
+type requestIdContextKey struct{}
+
+// ContextWithRequestId returns a context whose requests are sent and logged
+// with the given request ID, e.g. to propagate the ID of an incoming request.
+// By default every request gets a new random ID.
+func ContextWithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdContextKey{}, requestId)
+}
+
+// withRequestId returns a context carrying a new random request ID,
+// unless ctx already carries one.
+func withRequestId(ctx context.Context) context.Context {
+	if requestIdFrom(ctx) != "" {
+		return ctx
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return ctx
+	}
+	return ContextWithRequestId(ctx, hex.EncodeToString(id))
+}
+
+// requestIdFrom returns the request ID carried by ctx, or an empty string.
+func requestIdFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIdContextKey{}).(string)
+	return id
+}
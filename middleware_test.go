@@ -0,0 +1,88 @@
+package gofile_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	gofile "github.com/yaGatito/gofile-client"
+)
+
+func TestHookMiddleware(t *testing.T) {
+	srv := newTestServer(t)
+	var before, after int
+	var status int
+	hooks := gofile.HookMiddleware(
+		func(req *http.Request) error {
+			before++
+			return nil
+		},
+		func(req *http.Request, resp *http.Response, err error) {
+			after++
+			if resp != nil {
+				status = resp.StatusCode
+			}
+		},
+	)
+	client := newTestClient(t, srv, gofile.WithMiddleware(hooks))
+
+	if _, err := client.GetAccount(context.Background()); err != nil {
+		t.Fatalf("GetAccount: %v", err)
+	}
+	// GetAccount resolves the account ID, then fetches the account.
+	if before != 2 || after != 2 {
+		t.Errorf("hooks called %d and %d times, want 2 and 2", before, after)
+	}
+	if status != http.StatusOK {
+		t.Errorf("got status %d, want %d", status, http.StatusOK)
+	}
+}
+
+func TestHookMiddlewareAbortClosesUploadBody(t *testing.T) {
+	srv := newTestServer(t)
+	errAborted := errors.New("aborted")
+	abortUploads := gofile.HookMiddleware(func(req *http.Request) error {
+		if strings.HasSuffix(req.URL.Path, "/uploadfile") {
+			return errAborted
+		}
+		return nil
+	}, nil)
+	client := newTestClient(t, srv, gofile.WithMiddleware(abortUploads))
+
+	reader := newCloseRecorder(strings.NewReader("hello"))
+	_, err := client.UploadFile(context.Background(), srv.RootFolderId(srv.Token()), "a.txt", reader)
+	if !errors.Is(err, errAborted) {
+		t.Fatalf("got error %v, want the hook error", err)
+	}
+	reader.waitClosed(t)
+}
+
+func TestRequestIdMiddleware(t *testing.T) {
+	srv := newTestServer(t)
+	var ids []string
+	recordIds := gofile.HookMiddleware(func(req *http.Request) error {
+		ids = append(ids, req.Header.Get("X-Request-Id"))
+		return nil
+	}, nil)
+	client := newTestClient(t, srv, gofile.WithMiddleware(recordIds))
+
+	ctx := gofile.ContextWithRequestId(context.Background(), "incoming-id")
+	if _, err := client.GetAccount(ctx); err != nil {
+		t.Fatalf("GetAccount: %v", err)
+	}
+	if _, err := client.GetAccount(context.Background()); err != nil {
+		t.Fatalf("GetAccount: %v", err)
+	}
+
+	if len(ids) != 4 {
+		t.Fatalf("got %d requests, want 4", len(ids))
+	}
+	if ids[0] != "incoming-id" || ids[1] != "incoming-id" {
+		t.Errorf("got request IDs %q, want the propagated one", ids[:2])
+	}
+	if ids[2] == "" || ids[2] == "incoming-id" || ids[2] == ids[3] {
+		t.Errorf("got request IDs %q, want a new ID per request", ids[2:])
+	}
+}
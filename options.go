@@ -0,0 +1,186 @@
+package gofile
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures a GofileClient created by NewWithOptions or NewGuestWithOptions.
+type Option func(*GofileClient)
+
+// NewWithOptions creates a new GofileClient using the provided API key
+// and applies the given options on top of the defaults used by New.
+//
+// The function returns an error if apiKey is empty or the configured
+// endpoints are invalid.
+func NewWithOptions(apiKey string, opts ...Option) (*GofileClient, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("empty apiKey")
+	}
+	return newClientWithOptions(apiKey, false, opts)
+}
+
+// NewGuestWithOptions creates a new guest GofileClient, as NewGuest does,
+// and applies the given options on top of the defaults.
+func NewGuestWithOptions(opts ...Option) (*GofileClient, error) {
+	return newClientWithOptions("", true, opts)
+}
+
+func newClientWithOptions(apiKey string, guest bool, opts []Option) (*GofileClient, error) {
+	c := newClient(apiKey)
+	c.guest = guest
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.logger = newRedactingLogger(c.logger, c.secrets)
+	builtin := []Middleware{RequestIdMiddleware(), LoggingMiddleware(c.logger)}
+	c.doer = chainMiddleware(c.client, append(append(builtin, c.middleware...), transferMetricsMiddleware(c.metrics)))
+	if err := c.validateEndpoints(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// WithAPIBaseURL sets the base URL of the account and contents API,
+// "https://api.gofile.io" by default.
+func WithAPIBaseURL(baseURL string) Option {
+	return func(c *GofileClient) {
+		c.apiBaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithUploadURL sets the URL files are uploaded to,
+// "https://upload.gofile.io/uploadfile" by default.
+func WithUploadURL(uploadURL string) Option {
+	return func(c *GofileClient) {
+		c.uploadURL = uploadURL
+	}
+}
+
+// WithDownloadURLTemplate sets the template of download URLs.
+//
+// The template must contain three %s verbs, replaced in order by the server,
+// the file ID and the file name. The default is "https://%s.gofile.io/download/web/%s/%s".
+func WithDownloadURLTemplate(template string) Option {
+	return func(c *GofileClient) {
+		c.downloadURLTemplate = template
+	}
+}
+
+// WithUploadServerSelection enables discovery of the upload server instead of
+// always uploading to the configured upload URL.
+//
+// The selected server is cached for the configured TTL and replaced after
+// a failed upload. If discovery fails, the configured upload URL is used.
+func WithUploadServerSelection(selection UploadServerSelection) Option {
+	return func(c *GofileClient) {
+		c.uploadServers = newUploadServerSelector(selection)
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests.
+// A nil client keeps the default one.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *GofileClient) {
+		if client != nil {
+			c.client = client
+		}
+	}
+}
+
+// WithMiddleware appends middlewares to the chain every request is sent through.
+//
+// The first middleware is the outermost one. User middlewares run inside the
+// built-in request ID and logging middlewares, and wrap the HTTP client.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *GofileClient) {
+		for _, m := range middlewares {
+			if m != nil {
+				c.middleware = append(c.middleware, m)
+			}
+		}
+	}
+}
+
+// WithMetrics reports operation counts, errors, latencies and transferred
+// bytes to collector, e.g. one created with NewExpvarMetrics.
+// A nil collector keeps the default one, which discards metrics.
+func WithMetrics(collector MetricsCollector) Option {
+	return func(c *GofileClient) {
+		if collector != nil {
+			c.metrics = collector
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *GofileClient) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithLogger sets the structured logger of the client.
+// A nil logger keeps the default one writing text to stdout at the Info level.
+//
+// Request detail is logged at the Debug level. The bearer token and website
+// tokens are redacted from every record before it reaches the logger's handler.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *GofileClient) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// WithProgress reports the progress of every upload and download to fn,
+// at most once per interval. An interval <= 0 uses the default of 500ms.
+//
+// ContextWithProgress overrides fn for a single transfer.
+func WithProgress(fn ProgressFunc, interval time.Duration) Option {
+	return func(c *GofileClient) {
+		c.progress = fn
+		if interval > 0 {
+			c.progressInterval = interval
+		}
+	}
+}
+
+// WithBandwidthLimit caps the bandwidth shared by all uploads or all downloads
+// of the client, for operation OperationUpload or OperationDownload.
+// The cap can be changed later with GofileClient.SetBandwidthLimit.
+func WithBandwidthLimit(operation string, bytesPerSecond int64) Option {
+	return func(c *GofileClient) {
+		if bucket, ok := c.bandwidth[operation]; ok {
+			bucket.setRate(float64(bytesPerSecond), bandwidthChunkSize)
+		}
+	}
+}
+
+// WithDeleteCorruptUploads makes UploadFile delete the remote copy of a file
+// whose MD5 sum does not match the bytes that were sent.
+func WithDeleteCorruptUploads(enabled bool) Option {
+	return func(c *GofileClient) {
+		c.deleteCorruptUploads = enabled
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry failed requests.
+// Use NoRetry to disable retries.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *GofileClient) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithRateLimit sets the client-side limit of the given endpoint class.
+// A zero RateLimit disables the limit; 429 responses and Retry-After headers
+// still pause the class.
+func WithRateLimit(class EndpointClass, limit RateLimit) Option {
+	return func(c *GofileClient) {
+		c.limiter.set(class, limit)
+	}
+}
@@ -0,0 +1,85 @@
+package gofile_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	gofile "github.com/yaGatito/gofile-client"
+)
+
+func TestNewWithOptionsValidatesEndpoints(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  gofile.Option
+	}{
+		{"relative API base URL", gofile.WithAPIBaseURL("api.gofile.io")},
+		{"unparsable API base URL", gofile.WithAPIBaseURL("https://api.gofile.io/%zz")},
+		{"upload URL without host", gofile.WithUploadURL("https:///uploadfile")},
+		{"download template with two verbs", gofile.WithDownloadURLTemplate("https://%s.example.com/%s")},
+		{"download template with another verb", gofile.WithDownloadURLTemplate("https://%s.example.com/%s/%s?n=%d")},
+		{"upload server template without verb", gofile.WithUploadServerSelection(gofile.UploadServerSelection{
+			URLTemplate: "https://store.example.com/uploadfile",
+		})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := gofile.NewWithOptions("token", tt.opt); err == nil {
+				t.Error("NewWithOptions succeeded with an invalid endpoint")
+			}
+			if _, err := gofile.NewGuestWithOptions(tt.opt); err == nil {
+				t.Error("NewGuestWithOptions succeeded with an invalid endpoint")
+			}
+		})
+	}
+}
+
+func TestNewWithOptionsDefaults(t *testing.T) {
+	if _, err := gofile.NewWithOptions("token"); err != nil {
+		t.Errorf("NewWithOptions with the default endpoints: %v", err)
+	}
+	if _, err := gofile.NewWithOptions(""); err == nil {
+		t.Error("NewWithOptions succeeded without an API key")
+	}
+}
+
+func TestWithAPIBaseURLTrimsTrailingSlash(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv, gofile.WithAPIBaseURL(srv.URL+"/"))
+
+	if _, err := client.GetAccount(context.Background()); err != nil {
+		t.Errorf("GetAccount with a trailing slash in the base URL: %v", err)
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	srv := newTestServer(t)
+	var mu sync.Mutex
+	var userAgents []string
+	client := newTestClient(t, srv,
+		gofile.WithUserAgent("my-app/1.0"),
+		gofile.WithMiddleware(gofile.HookMiddleware(func(req *http.Request) error {
+			mu.Lock()
+			defer mu.Unlock()
+			userAgents = append(userAgents, req.Header.Get("User-Agent"))
+			return nil
+		}, nil)),
+	)
+
+	uploadTestFile(t, client, srv, "a.txt", []byte("a"))
+	if _, err := client.GetAccount(context.Background()); err != nil {
+		t.Fatalf("GetAccount: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(userAgents) == 0 {
+		t.Fatal("no request was sent")
+	}
+	for _, userAgent := range userAgents {
+		if userAgent != "my-app/1.0" {
+			t.Errorf("sent User-Agent %q, want my-app/1.0", userAgent)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package gofile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	defaultParallelWorkers        = 4
+	defaultParallelSegmentSize    = 8 << 20
+	defaultParallelSegmentAttempt = 3
+)
+
+// ParallelDownloadOptions configures DownloadParallel.
+type ParallelDownloadOptions struct {
+	// WebsiteToken is sent when retrieving the file metadata.
+	WebsiteToken string
+	// Workers is the number of segments downloaded concurrently. Defaults to 4.
+	Workers int
+	// SegmentSize is the size of a single range request in bytes. Defaults to 8 MiB.
+	SegmentSize int64
+	// MaxSegmentAttempts is the number of attempts per segment before
+	// the download fails. It replaces the client's RetryPolicy for segment
+	// requests, and only server-side failures are retried. Defaults to 3.
+	MaxSegmentAttempts int
+}
+
+// fileSegment is a byte range of a file downloaded by a single worker.
+type fileSegment struct {
+	offset int64
+	length int64
+}
+
+// DownloadParallel downloads a file into w by splitting it into segments
+// fetched concurrently with HTTP Range requests.
+//
+// The file size and servers are retrieved with GetFileInfo. A failed segment
+// is retried on its own, rotating through the servers of the file, without
+// restarting the rest of the download.
+//
+// It returns the size of the file once every segment is written. On failure
+// it returns 0, although segments completed before the failure may already
+// have been written to w.
+func (c *GofileClient) DownloadParallel(
+	ctx context.Context,
+	fileId string,
+	w io.WriterAt,
+	opts ParallelDownloadOptions,
+) (_ int64, err error) {
+	defer c.observeOperation("DownloadParallel", time.Now(), &err)
+
+	if fileId == "" {
+		return 0, fmt.Errorf("fileId is not specified")
+	}
+	if w == nil {
+		return 0, fmt.Errorf("writer is not specified")
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = defaultParallelWorkers
+	}
+	if opts.SegmentSize <= 0 {
+		opts.SegmentSize = defaultParallelSegmentSize
+	}
+	if opts.MaxSegmentAttempts <= 0 {
+		opts.MaxSegmentAttempts = defaultParallelSegmentAttempt
+	}
+
+	fileInfo, err := c.GetFileInfo(ctx, opts.WebsiteToken, fileId)
+	if err != nil {
+		return 0, fmt.Errorf("retrieving file info: %w", err)
+	}
+	size := fileInfo.Data.Size
+	if size <= 0 {
+		return 0, fmt.Errorf("unknown size of file %s", fileId)
+	}
+	servers := downloadServerOrder(fileInfo.Data.ServerSelected, fileInfo.Data.Servers)
+	if len(servers) == 0 {
+		return 0, fmt.Errorf("no server available for file %s", fileId)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	segments := make(chan fileSegment)
+	go func() {
+		defer close(segments)
+		for offset := int64(0); offset < size; offset += opts.SegmentSize {
+			segment := fileSegment{offset: offset, length: min(opts.SegmentSize, size-offset)}
+			select {
+			case segments <- segment:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	tracker := c.newProgressTracker(ctx, OperationDownload, fileInfo.Data.Name, 0, size)
+	defer tracker.finish()
+	throttle := c.newThrottle(ctx, OperationDownload)
+
+	var wg sync.WaitGroup
+	for range min(int64(opts.Workers), (size+opts.SegmentSize-1)/opts.SegmentSize) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for segment := range segments {
+				err := c.downloadSegment(ctx, fileInfo, servers, segment, w, opts.MaxSegmentAttempts, tracker, throttle)
+				if err != nil {
+					cancel(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := context.Cause(ctx); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// downloadSegment downloads a single segment into w, retrying it up to
+// maxAttempts times and switching server after every failed attempt.
+//
+// Segment requests are not retried by the client's RetryPolicy on top of these
+// attempts, and failures that are not server-side, e.g. 401 or 404, are not retried.
+func (c *GofileClient) downloadSegment(
+	ctx context.Context,
+	fileInfo GetFileInfoResponseBody,
+	servers []string,
+	segment fileSegment,
+	w io.WriterAt,
+	maxAttempts int,
+	tracker *progressTracker,
+	throttle *throttle,
+) error {
+
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		server := servers[attempt%len(servers)]
+		err = c.downloadSegmentOnce(ctx, server, fileInfo, segment, w, tracker, throttle)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil || errors.Is(err, ErrRangeNotSupported) || !isServerFailure(err) {
+			return err
+		}
+		c.logger.WarnContext(ctx, "Download segment failed", "offset", segment.offset, "file_id", fileInfo.Data.Id, "server", server, "attempt", attempt+1, "error", err)
+	}
+	return fmt.Errorf("segment at offset %d failed after %d attempts: %w", segment.offset, maxAttempts, err)
+}
+
+func (c *GofileClient) downloadSegmentOnce(
+	ctx context.Context,
+	server string,
+	fileInfo GetFileInfoResponseBody,
+	segment fileSegment,
+	w io.WriterAt,
+	tracker *progressTracker,
+	throttle *throttle,
+) error {
+
+	resp, err := c.downloadRange(withoutRetries(ctx), server, fileInfo.Data.Id, fileInfo.Data.Name, segment.offset, segment.length, "")
+	if err != nil {
+		return err
+	}
+	body, err := rangeBody(resp, segment.offset, segment.length)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	body = throttle.reader(ctx, body)
+
+	segmentWriter := &progressWriter{w: io.NewOffsetWriter(w, segment.offset), tracker: tracker}
+	written, err := io.Copy(segmentWriter, io.LimitReader(body, segment.length))
+	if err == nil && written != segment.length {
+		err = fmt.Errorf("received %d bytes, expected %d: %w", written, segment.length, io.ErrUnexpectedEOF)
+	}
+	if err != nil {
+		// The segment is downloaded again from its start.
+		tracker.add(-written)
+		return err
+	}
+	return nil
+}
+
+// progressWriter reports the bytes written through it to a tracker.
+type progressWriter struct {
+	w       io.Writer
+	tracker *progressTracker
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.tracker.add(int64(n))
+	return n, err
+}
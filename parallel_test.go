@@ -0,0 +1,119 @@
+package gofile_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	gofile "github.com/yaGatito/gofile-client"
+	"github.com/yaGatito/gofile-client/gofiletest"
+)
+
+func TestDownloadParallel(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	data := testData(100<<10 + 123)
+	uploaded := uploadTestFile(t, client, srv, "data.bin", data)
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "data.bin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	written, err := client.DownloadParallel(context.Background(), uploaded.Data.Id, file, gofile.ParallelDownloadOptions{
+		Workers:     3,
+		SegmentSize: 16 << 10,
+	})
+	if err != nil {
+		t.Fatalf("DownloadParallel: %v", err)
+	}
+	if written != int64(len(data)) {
+		t.Errorf("wrote %d bytes, want %d", written, len(data))
+	}
+	got, err := os.ReadFile(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Error("downloaded bytes differ from the uploaded ones")
+	}
+}
+
+func TestDownloadParallelRetriesSegmentOnServerFailure(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+	data := testData(1000)
+	uploaded := uploadTestFile(t, client, srv, "data.bin", data)
+
+	srv.InjectFault(gofiletest.Fault{Kind: gofiletest.FaultServerError, PathPrefix: "/" + gofiletest.StoreServer + "/download/", Count: 1})
+	buf := &writerAtBuffer{}
+	if _, err := client.DownloadParallel(context.Background(), uploaded.Data.Id, buf, gofile.ParallelDownloadOptions{Workers: 1}); err != nil {
+		t.Fatalf("DownloadParallel: %v", err)
+	}
+	if got := recorder.count(http.MethodGet, "/"+gofiletest.StoreServer+"/download/"); got != 2 {
+		t.Errorf("sent %d segment requests, want 2", got)
+	}
+	if !bytes.Equal(buf.data, data) {
+		t.Error("downloaded bytes differ from the uploaded ones")
+	}
+}
+
+func TestDownloadParallelSegmentsBypassRetryPolicy(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+	uploaded := uploadTestFile(t, client, srv, "data.bin", testData(1000))
+
+	srv.InjectFault(gofiletest.Fault{Kind: gofiletest.FaultServerError, PathPrefix: "/" + gofiletest.StoreServer + "/download/", Count: 1})
+	written, err := client.DownloadParallel(context.Background(), uploaded.Data.Id, &writerAtBuffer{}, gofile.ParallelDownloadOptions{
+		Workers:            1,
+		MaxSegmentAttempts: 1,
+	})
+	if err == nil {
+		t.Fatal("DownloadParallel succeeded, want the segment failure")
+	}
+	if written != 0 {
+		t.Errorf("failed download returned %d bytes, want 0", written)
+	}
+	if got := recorder.count(http.MethodGet, "/"+gofiletest.StoreServer+"/download/"); got != 1 {
+		t.Errorf("sent %d segment requests, want 1", got)
+	}
+}
+
+func TestDownloadParallelDoesNotRetryClientErrors(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+	uploaded := uploadTestFile(t, client, srv, "data.bin", testData(1000))
+
+	srv.InjectFault(gofiletest.Fault{Kind: gofiletest.FaultServerError, StatusCode: http.StatusNotFound, PathPrefix: "/" + gofiletest.StoreServer + "/download/"})
+	_, err := client.DownloadParallel(context.Background(), uploaded.Data.Id, &writerAtBuffer{}, gofile.ParallelDownloadOptions{Workers: 1})
+	if !errors.Is(err, gofile.ErrNotFound) {
+		t.Fatalf("got error %v, want ErrNotFound", err)
+	}
+	if got := recorder.count(http.MethodGet, "/"+gofiletest.StoreServer+"/download/"); got != 1 {
+		t.Errorf("sent %d segment requests, want 1", got)
+	}
+}
+
+// writerAtBuffer is an in-memory io.WriterAt.
+type writerAtBuffer struct {
+	mu   sync.Mutex
+	data []byte
+}
+
+func (b *writerAtBuffer) WriteAt(p []byte, off int64) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if end := off + int64(len(p)); end > int64(len(b.data)) {
+		b.data = append(b.data, make([]byte, end-int64(len(b.data)))...)
+	}
+	return copy(b.data[off:], p), nil
+}
@@ -0,0 +1,211 @@
+package gofile
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// defaultProgressInterval is the minimum delay between two progress callbacks
+// of the same transfer.
+const defaultProgressInterval = 500 * time.Millisecond
+
+// Transfer operations reported in Progress.
+const (
+	OperationUpload   = "upload"
+	OperationDownload = "download"
+)
+
+// Progress describes the state of a transfer in progress.
+type Progress struct {
+	// Operation is OperationUpload or OperationDownload.
+	Operation string
+	// Name is the name of the transferred file.
+	Name string
+	// Bytes is the number of bytes transferred so far.
+	Bytes int64
+	// Total is the size of the transfer, or -1 if unknown.
+	Total int64
+	// Rate is the transfer rate since the previous report, in bytes per second.
+	Rate float64
+	// AverageRate is the transfer rate since the start, in bytes per second.
+	AverageRate float64
+	// ETA is the estimated time left, or -1 if unknown.
+	ETA time.Duration
+	// Done is set on the last report of the transfer.
+	Done bool
+}
+
+// ProgressFunc receives progress reports of a transfer.
+//
+// Reports of a single transfer are delivered sequentially, at most once per
+// reporting interval, plus a final report with Done set.
+type ProgressFunc func(Progress)
+
+type progressContextKey struct{}
+
+// ContextWithProgress returns a context reporting the progress of transfers
+// made with it to fn, overriding the ProgressFunc configured on the client.
+func ContextWithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressContextKey{}, fn)
+}
+
+// progressFunc returns the progress callback of a transfer made with ctx, or nil.
+func (c *GofileClient) progressFunc(ctx context.Context) ProgressFunc {
+	if fn, ok := ctx.Value(progressContextKey{}).(ProgressFunc); ok {
+		return fn
+	}
+	return c.progress
+}
+
+// progressTracker accumulates the bytes of a transfer and reports them,
+// throttled to the configured interval.
+// A nil tracker ignores every call.
+type progressTracker struct {
+	mu        sync.Mutex
+	fn        ProgressFunc
+	interval  time.Duration
+	operation string
+	name      string
+	total     int64
+	bytes     int64
+	base      int64
+	start     time.Time
+	lastTime  time.Time
+	lastBytes int64
+	done      bool
+}
+
+// newProgressTracker returns a tracker for a transfer of total bytes,
+// starting at offset, or nil if no progress callback applies to ctx.
+func (c *GofileClient) newProgressTracker(ctx context.Context, operation, name string, offset, total int64) *progressTracker {
+	fn := c.progressFunc(ctx)
+	if fn == nil {
+		return nil
+	}
+	if total < 0 {
+		total = -1
+	}
+	now := time.Now()
+	return &progressTracker{
+		fn:        fn,
+		interval:  c.progressInterval,
+		operation: operation,
+		name:      name,
+		total:     total,
+		bytes:     offset,
+		base:      offset,
+		start:     now,
+		lastTime:  now,
+		lastBytes: offset,
+	}
+}
+
+// add records n transferred bytes; a negative n discards bytes
+// of a failed attempt.
+func (t *progressTracker) add(n int64) {
+	if t == nil || n == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return
+	}
+	t.bytes += n
+	if now := time.Now(); now.Sub(t.lastTime) >= t.interval {
+		t.report(now)
+	}
+}
+
+// finish sends the final report. Later calls are ignored.
+func (t *progressTracker) finish() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return
+	}
+	t.done = true
+	t.report(time.Now())
+}
+
+// report calls the callback with the current state. It must be called with mu held.
+func (t *progressTracker) report(now time.Time) {
+	p := Progress{
+		Operation: t.operation,
+		Name:      t.name,
+		Bytes:     t.bytes,
+		Total:     t.total,
+		ETA:       -1,
+		Done:      t.done,
+	}
+	if elapsed := now.Sub(t.lastTime).Seconds(); elapsed > 0 {
+		p.Rate = float64(t.bytes-t.lastBytes) / elapsed
+	}
+	if elapsed := now.Sub(t.start).Seconds(); elapsed > 0 {
+		p.AverageRate = float64(t.bytes-t.base) / elapsed
+	}
+	if t.done {
+		p.ETA = 0
+	} else if t.total >= 0 && p.AverageRate > 0 {
+		p.ETA = time.Duration(float64(max(t.total-t.bytes, 0)) / p.AverageRate * float64(time.Second))
+	}
+	t.lastTime = now
+	t.lastBytes = t.bytes
+	t.fn(p)
+}
+
+// progressReader reports the bytes read through it to a tracker,
+// finishing the transfer on EOF or Close.
+type progressReader struct {
+	io.ReadCloser
+	tracker *progressTracker
+}
+
+// withProgress wraps r so that reads are reported to the tracker.
+// It returns r unchanged if tracker is nil.
+func withProgress(r io.ReadCloser, tracker *progressTracker) io.ReadCloser {
+	if tracker == nil {
+		return r
+	}
+	return &progressReader{ReadCloser: r, tracker: tracker}
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.tracker.add(int64(n))
+	if err == io.EOF {
+		r.tracker.finish()
+	}
+	return n, err
+}
+
+func (r *progressReader) Close() error {
+	r.tracker.finish()
+	return r.ReadCloser.Close()
+}
+
+// readerSize returns the number of bytes left in r when it can be
+// determined without reading it, or -1.
+func readerSize(r io.Reader) int64 {
+	switch v := r.(type) {
+	case *os.File:
+		stat, err := v.Stat()
+		if err != nil || !stat.Mode().IsRegular() {
+			return -1
+		}
+		offset, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		return max(stat.Size()-offset, 0)
+	case interface{ Len() int }:
+		return int64(v.Len())
+	}
+	return -1
+}
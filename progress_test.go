@@ -0,0 +1,172 @@
+package gofile_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gofile "github.com/yaGatito/gofile-client"
+	"github.com/yaGatito/gofile-client/gofiletest"
+)
+
+// progressRecorder records the progress reports of transfers.
+type progressRecorder struct {
+	mu      sync.Mutex
+	reports []gofile.Progress
+}
+
+func (r *progressRecorder) record(p gofile.Progress) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, p)
+}
+
+func (r *progressRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = nil
+}
+
+func (r *progressRecorder) all() []gofile.Progress {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]gofile.Progress(nil), r.reports...)
+}
+
+// downloadAll downloads the file and reads its body to the end.
+func downloadAll(t *testing.T, ctx context.Context, client *gofile.GofileClient, fileId, name string) {
+	t.Helper()
+	body, err := client.DownloadFile(ctx, gofiletest.StoreServer, fileId, name)
+	if err != nil {
+		t.Fatalf("DownloadFile: %v", err)
+	}
+	defer body.Close()
+	if _, err := io.Copy(io.Discard, body); err != nil {
+		t.Fatalf("reading download: %v", err)
+	}
+}
+
+func TestProgressReportsOnlyDoneWithinInterval(t *testing.T) {
+	srv := newTestServer(t)
+	progress := &progressRecorder{}
+	client := newTestClient(t, srv, gofile.WithProgress(progress.record, time.Hour))
+	data := testData(256 << 10)
+	uploaded := uploadTestFile(t, client, srv, "data.bin", data)
+	progress.reset()
+
+	downloadAll(t, context.Background(), client, uploaded.Data.Id, "data.bin")
+
+	reports := progress.all()
+	if len(reports) != 1 {
+		t.Fatalf("got %d reports within the interval, want only the final one", len(reports))
+	}
+	final := reports[0]
+	if !final.Done || final.Operation != gofile.OperationDownload || final.Name != "data.bin" {
+		t.Errorf("got final report %+v, want a done download of data.bin", final)
+	}
+	if final.Bytes != int64(len(data)) || final.Total != int64(len(data)) || final.ETA != 0 {
+		t.Errorf("got %d of %d bytes with ETA %v, want %d of %d with no ETA",
+			final.Bytes, final.Total, final.ETA, len(data), len(data))
+	}
+}
+
+func TestProgressReportsEveryInterval(t *testing.T) {
+	srv := newTestServer(t)
+	progress := &progressRecorder{}
+	client := newTestClient(t, srv, gofile.WithProgress(progress.record, time.Nanosecond))
+	data := testData(256 << 10)
+	uploaded := uploadTestFile(t, client, srv, "data.bin", data)
+	progress.reset()
+
+	downloadAll(t, context.Background(), client, uploaded.Data.Id, "data.bin")
+
+	reports := progress.all()
+	if len(reports) < 2 {
+		t.Fatalf("got %d reports, want intermediate ones", len(reports))
+	}
+	for i, report := range reports {
+		if report.Done != (i == len(reports)-1) {
+			t.Errorf("report %d of %d has Done %t, want it on the last report only", i+1, len(reports), report.Done)
+		}
+		if i > 0 && report.Bytes < reports[i-1].Bytes {
+			t.Errorf("report %d went back from %d to %d bytes", i+1, reports[i-1].Bytes, report.Bytes)
+		}
+	}
+	if final := reports[len(reports)-1]; final.Bytes != int64(len(data)) {
+		t.Errorf("final report has %d bytes, want %d", final.Bytes, len(data))
+	}
+}
+
+func TestContextWithProgressOverridesClient(t *testing.T) {
+	srv := newTestServer(t)
+	clientProgress := &progressRecorder{}
+	client := newTestClient(t, srv, gofile.WithProgress(clientProgress.record, time.Hour))
+	uploaded := uploadTestFile(t, client, srv, "data.bin", testData(1000))
+	clientProgress.reset()
+
+	ctxProgress := &progressRecorder{}
+	ctx := gofile.ContextWithProgress(context.Background(), ctxProgress.record)
+	downloadAll(t, ctx, client, uploaded.Data.Id, "data.bin")
+
+	if got := len(clientProgress.all()); got != 0 {
+		t.Errorf("client callback got %d reports, want none", got)
+	}
+	if reports := ctxProgress.all(); len(reports) == 0 || !reports[len(reports)-1].Done {
+		t.Errorf("context callback got %+v, want a final report", reports)
+	}
+}
+
+func TestProgressRewindsFailedParallelSegment(t *testing.T) {
+	srv := newTestServer(t)
+	data := testData(64 << 10)
+
+	// The first segment request fails after half of the segment.
+	var once sync.Once
+	interrupt := func(next gofile.Doer) gofile.Doer {
+		return gofile.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.Do(req)
+			if err == nil && strings.Contains(req.URL.Path, "/download/") {
+				once.Do(func() {
+					resp.Body = &failingReader{r: resp.Body, remaining: 8 << 10}
+				})
+			}
+			return resp, err
+		})
+	}
+	progress := &progressRecorder{}
+	client := newTestClient(t, srv, gofile.WithMiddleware(interrupt), gofile.WithProgress(progress.record, time.Nanosecond))
+	uploaded := uploadTestFile(t, client, srv, "data.bin", data)
+	progress.reset()
+
+	_, err := client.DownloadParallel(context.Background(), uploaded.Data.Id, &writerAtBuffer{}, gofile.ParallelDownloadOptions{
+		Workers:     1,
+		SegmentSize: 16 << 10,
+	})
+	if err != nil {
+		t.Fatalf("DownloadParallel: %v", err)
+	}
+
+	reports := progress.all()
+	if len(reports) == 0 {
+		t.Fatal("got no progress report")
+	}
+	rewound := false
+	for i, report := range reports {
+		if report.Bytes > int64(len(data)) {
+			t.Errorf("report %d has %d bytes, more than the %d bytes of the file", i+1, report.Bytes, len(data))
+		}
+		if i > 0 && report.Bytes < reports[i-1].Bytes {
+			rewound = true
+		}
+	}
+	if !rewound {
+		t.Error("the bytes of the failed segment were not discarded")
+	}
+	if final := reports[len(reports)-1]; !final.Done || final.Bytes != int64(len(data)) {
+		t.Errorf("got final report %+v, want %d bytes done", final, len(data))
+	}
+}
@@ -0,0 +1,244 @@
+package gofile
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultRateLimitPause is how long an endpoint class is paused after
+// a 429 response that carries no Retry-After header.
+const defaultRateLimitPause = time.Second
+
+// maxRateLimitedAttempts bounds the number of times a single request is resent
+// after being rate limited, on top of the attempts allowed by the RetryPolicy.
+const maxRateLimitedAttempts = 8
+
+// EndpointClass groups GoFile endpoints that share a client-side rate limit.
+type EndpointClass string
+
+const (
+	// EndpointAPI covers the api.gofile.io account and contents endpoints.
+	EndpointAPI EndpointClass = "api"
+	// EndpointUpload covers the upload servers.
+	EndpointUpload EndpointClass = "upload"
+	// EndpointDownload covers the download servers.
+	EndpointDownload EndpointClass = "download"
+)
+
+// RateLimit is a token-bucket limit on the number of requests sent to an endpoint class.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained request rate. Values <= 0 disable the limit.
+	RequestsPerSecond float64
+	// Burst is the number of requests that may be sent at once. Values < 1 are treated as 1.
+	Burst int
+}
+
+// RateLimiterState is a snapshot of the limiter of an endpoint class.
+type RateLimiterState struct {
+	Limit RateLimit
+	// Tokens is the number of requests that can be sent immediately.
+	// It is negative while requests are queued.
+	Tokens float64
+	// PausedUntil is set when GoFile asked the client to back off,
+	// via a 429 response or a Retry-After header.
+	PausedUntil time.Time
+}
+
+// DefaultRateLimits returns the limits used by clients unless configured otherwise.
+// Only the API endpoints are limited; every class honors 429 and Retry-After.
+func DefaultRateLimits() map[EndpointClass]RateLimit {
+	return map[EndpointClass]RateLimit{
+		EndpointAPI:      {RequestsPerSecond: 4, Burst: 8},
+		EndpointUpload:   {},
+		EndpointDownload: {},
+	}
+}
+
+// RateLimiterState returns a snapshot of the limiter of every endpoint class.
+func (c *GofileClient) RateLimiterState() map[EndpointClass]RateLimiterState {
+	return c.limiter.state()
+}
+
+// SetRateLimit sets the client-side limit of the given endpoint class.
+// A zero RateLimit disables the limit; 429 responses and Retry-After headers
+// still pause the class. It is safe to call while requests are in flight;
+// requests waiting on the limiter switch to the new limit.
+func (c *GofileClient) SetRateLimit(class EndpointClass, limit RateLimit) {
+	c.limiter.set(class, limit)
+}
+
+// endpointClassOf returns the endpoint class the request belongs to.
+func endpointClassOf(req *http.Request) EndpointClass {
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/uploadfile"):
+		return EndpointUpload
+	case isFileDownload(req):
+		return EndpointDownload
+	default:
+		return EndpointAPI
+	}
+}
+
+// rateLimiter holds one token bucket per endpoint class.
+type rateLimiter struct {
+	buckets map[EndpointClass]*tokenBucket
+}
+
+func newRateLimiter(limits map[EndpointClass]RateLimit) *rateLimiter {
+	l := &rateLimiter{buckets: make(map[EndpointClass]*tokenBucket)}
+	for _, class := range []EndpointClass{EndpointAPI, EndpointUpload, EndpointDownload} {
+		limit := limits[class]
+		l.buckets[class] = newTokenBucket(limit.RequestsPerSecond, float64(limit.Burst))
+	}
+	return l
+}
+
+// wait blocks until a request of the given class may be sent or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context, class EndpointClass) error {
+	return l.buckets[class].wait(ctx, 1)
+}
+
+// pause stops requests of the given class for the given duration.
+func (l *rateLimiter) pause(class EndpointClass, d time.Duration) {
+	l.buckets[class].pause(time.Now().Add(d))
+}
+
+// set replaces the limit of the given class.
+func (l *rateLimiter) set(class EndpointClass, limit RateLimit) {
+	if bucket, ok := l.buckets[class]; ok {
+		bucket.setRate(limit.RequestsPerSecond, float64(limit.Burst))
+	}
+}
+
+func (l *rateLimiter) state() map[EndpointClass]RateLimiterState {
+	states := make(map[EndpointClass]RateLimiterState, len(l.buckets))
+	for class, bucket := range l.buckets {
+		rate, burst, tokens, pausedUntil := bucket.snapshot()
+		states[class] = RateLimiterState{
+			Limit:       RateLimit{RequestsPerSecond: rate, Burst: int(burst)},
+			Tokens:      tokens,
+			PausedUntil: pausedUntil,
+		}
+	}
+	return states
+}
+
+// tokenBucket is a reservation-based token bucket.
+//
+// Callers take tokens up front, letting the balance go negative, and sleep
+// for the time needed to pay the debt back. Waiters are therefore served
+// in arrival order. A rate <= 0 disables the limit but still honors pauses.
+//
+// Changing the rate drops the debt and wakes the waiters,
+// which take their tokens again at the new rate.
+type tokenBucket struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	// rateChanged is closed and replaced when the rate changes.
+	rateChanged chan struct{}
+}
+
+func newTokenBucket(rate, burst float64) *tokenBucket {
+	burst = max(burst, 1)
+	return &tokenBucket{
+		rate:        rate,
+		burst:       burst,
+		tokens:      burst,
+		last:        time.Now(),
+		rateChanged: make(chan struct{}),
+	}
+}
+
+// wait takes n tokens, blocking until they are available or ctx is done.
+// Tokens are given back if ctx is done before they become available.
+func (b *tokenBucket) wait(ctx context.Context, n float64) error {
+	b.mu.Lock()
+	delay, rateChanged := b.reserve(n)
+	b.mu.Unlock()
+
+	for delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+			return nil
+		case <-rateChanged:
+			timer.Stop()
+			b.mu.Lock()
+			delay, rateChanged = b.reserve(n)
+			b.mu.Unlock()
+		case <-ctx.Done():
+			timer.Stop()
+			b.mu.Lock()
+			// The reservation is only still owed if the rate did not change since.
+			if b.rate > 0 && b.rateChanged == rateChanged {
+				b.tokens = min(b.tokens+n, b.burst)
+			}
+			b.mu.Unlock()
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// reserve takes n tokens and returns how long the caller must wait for them,
+// along with the channel closed on the next rate change. b.mu must be held.
+func (b *tokenBucket) reserve(n float64) (time.Duration, chan struct{}) {
+	now := time.Now()
+	b.advance(now)
+	var delay time.Duration
+	if b.rate > 0 {
+		b.tokens -= n
+		if b.tokens < 0 {
+			delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+		}
+	}
+	if b.pausedUntil.After(now) {
+		delay = max(delay, b.pausedUntil.Sub(now))
+	}
+	return delay, b.rateChanged
+}
+
+// pause blocks every waiter until the given moment.
+func (b *tokenBucket) pause(until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+}
+
+// setRate changes the rate and burst. The debt owed by waiters is dropped
+// and they are woken up to take their tokens again at the new rate.
+func (b *tokenBucket) setRate(rate, burst float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance(time.Now())
+	b.rate = rate
+	b.burst = max(burst, 1)
+	b.tokens = min(max(b.tokens, 0), b.burst)
+	close(b.rateChanged)
+	b.rateChanged = make(chan struct{})
+}
+
+func (b *tokenBucket) snapshot() (rate, burst, tokens float64, pausedUntil time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance(time.Now())
+	return b.rate, b.burst, b.tokens, b.pausedUntil
+}
+
+// advance refills the bucket for the time elapsed since the last update.
+func (b *tokenBucket) advance(now time.Time) {
+	if b.rate > 0 {
+		elapsed := now.Sub(b.last).Seconds()
+		b.tokens = min(b.tokens+elapsed*b.rate, b.burst)
+	}
+	b.last = now
+}
@@ -0,0 +1,131 @@
+package gofile_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	gofile "github.com/yaGatito/gofile-client"
+	"github.com/yaGatito/gofile-client/gofiletest"
+)
+
+func TestTokenBucketWaitsForDebt(t *testing.T) {
+	bucket := gofile.NewTokenBucket(100, 1)
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 6 {
+		if err := bucket.Wait(ctx, 1); err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+	}
+	// The first token is available at once, the other 5 take 10ms each.
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("6 tokens at 100/s took %v, want at least 50ms", elapsed)
+	}
+}
+
+func TestTokenBucketSetRateWakesWaiters(t *testing.T) {
+	bucket := gofile.NewTokenBucket(1, 1)
+	ctx := context.Background()
+	if err := bucket.Wait(ctx, 1); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- bucket.Wait(ctx, 10) }()
+	time.Sleep(20 * time.Millisecond)
+	bucket.SetRate(0, 1)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter kept the delay of the previous rate")
+	}
+}
+
+func TestTokenBucketCanceledWaitGivesTokensBack(t *testing.T) {
+	bucket := gofile.NewTokenBucket(10, 1)
+	if err := bucket.Wait(context.Background(), 1); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := bucket.Wait(ctx, 100); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want context.DeadlineExceeded", err)
+	}
+
+	// Without the 100 canceled tokens, the next one is due within 100ms.
+	start := time.Now()
+	if err := bucket.Wait(context.Background(), 1); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Wait took %v after a canceled reservation", elapsed)
+	}
+}
+
+func TestTokenBucketPause(t *testing.T) {
+	bucket := gofile.NewTokenBucket(0, 1)
+	bucket.Pause(time.Now().Add(50 * time.Millisecond))
+
+	start := time.Now()
+	if err := bucket.Wait(context.Background(), 1); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("Wait returned after %v during a 50ms pause", elapsed)
+	}
+}
+
+func TestRateLimitedRequestIsResentAfterPause(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()), gofile.WithRetryPolicy(gofile.NoRetry()))
+
+	srv.InjectFault(gofiletest.Fault{Kind: gofiletest.FaultRateLimit, PathPrefix: "/accounts/getid", Count: 1})
+	start := time.Now()
+	if _, err := client.GetAccount(context.Background()); err != nil {
+		t.Fatalf("GetAccount: %v", err)
+	}
+	if got := recorder.count(http.MethodGet, "/accounts/getid"); got != 2 {
+		t.Errorf("sent %d requests, want 2", got)
+	}
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("request was resent after %v, before the limiter pause", elapsed)
+	}
+}
+
+func TestRateLimitedRequestHonorsContext(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+
+	srv.InjectFault(gofiletest.Fault{Kind: gofiletest.FaultRateLimit, PathPrefix: "/accounts/getid", RetryAfter: time.Minute})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetAccount(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want context.DeadlineExceeded", err)
+	}
+
+	state := client.RateLimiterState()[gofile.EndpointAPI]
+	if time.Until(state.PausedUntil) < 30*time.Second {
+		t.Errorf("API class paused until %v, want about a minute from now", state.PausedUntil)
+	}
+}
+
+func TestSetRateLimit(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+
+	limit := gofile.RateLimit{RequestsPerSecond: 2, Burst: 3}
+	client.SetRateLimit(gofile.EndpointAPI, limit)
+	if got := client.RateLimiterState()[gofile.EndpointAPI].Limit; got != limit {
+		t.Errorf("got limit %+v, want %+v", got, limit)
+	}
+}
@@ -12,7 +12,7 @@ const websiteTokenHeader = "X-Website-Token"
 // createGetFileInfoRequest builds an HTTP GET request for retrieving
 // detailed metadata for the specified file ID.
 func (c *GofileClient) createGetFileInfoRequest(ctx context.Context, wsToken, fileId string) (*http.Request, error) {
-	url := fmt.Sprintf("%s%s", contentsBaseURL, url.PathEscape(fileId))
+	url := fmt.Sprintf("%s%s", c.contentsBaseURL(), url.PathEscape(fileId))
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	req.Header.Set(websiteTokenHeader, wsToken)
 	if err != nil {
@@ -21,10 +21,21 @@ func (c *GofileClient) createGetFileInfoRequest(ctx context.Context, wsToken, fi
 	return req, nil
 }
 
+// createGetFolderContentsRequest builds an HTTP GET request for retrieving
+// the metadata and children of the specified folder ID.
+func (c *GofileClient) createGetFolderContentsRequest(ctx context.Context, folderId string) (*http.Request, error) {
+	url := fmt.Sprintf("%s%s", c.contentsBaseURL(), url.PathEscape(folderId))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating 'getFolderContents' request: %w", err)
+	}
+	return req, nil
+}
+
 // createGetIdRequest builds an HTTP GET request for retrieving
 // the account ID associated with the API key in use.
 func (c *GofileClient) createGetIdRequest(ctx context.Context) (*http.Request, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, accountsBaseURL+"getid", nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.accountsBaseURL()+"getid", nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating 'getid' request: %w", err)
 	}
@@ -35,7 +46,7 @@ func (c *GofileClient) createGetIdRequest(ctx context.Context) (*http.Request, e
 // createGetAccountInfoRequest builds an HTTP GET request for retrieving
 // account metadata for the specified account ID.
 func (c *GofileClient) createGetAccountInfoRequest(ctx context.Context, accountId string) (*http.Request, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, accountsBaseURL+accountId, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.accountsBaseURL()+accountId, nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating 'getAccountInfo' request: %w", err)
 	}
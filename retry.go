@@ -0,0 +1,192 @@
+package gofile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried by the client.
+//
+// A request is only retried when its body can be replayed. Uploads stream
+// their body through a pipe and are therefore never retried.
+//
+// Requests that are not idempotent, such as CreateFolder, CopyContents,
+// MoveContents, ImportContents and DeleteContents, are only retried when
+// they were rate limited or could not reach the server, unless
+// RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts.
+	MaxBackoff time.Duration
+	// Multiplier grows the delay after every attempt. Values below 1 are treated as 1.
+	Multiplier float64
+	// Jitter randomizes every delay by up to the given fraction, between 0 and 1.
+	Jitter float64
+	// Retryable classifies errors returned by an attempt.
+	// If nil, IsRetryable is used.
+	Retryable func(err error) bool
+	// RetryNonIdempotent allows retrying POST, PUT and DELETE requests after
+	// any retryable error. A failure that happens after the server applied such
+	// a request, e.g. a connection reset, then leads to duplicate folders or copies.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the policy used by clients unless configured otherwise:
+// up to 3 attempts with exponential backoff starting at 500ms.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// NoRetry returns a policy that never retries.
+func NoRetry() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// IsRetryable reports whether err is a transient failure worth retrying:
+// transport errors, 5xx responses and rate limiting.
+// Context cancellation and other API errors are not retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatus >= http.StatusInternalServerError || errors.Is(apiErr, ErrRateLimited)
+	}
+	return true
+}
+
+// SetRetryPolicy sets the policy used to retry failed requests.
+// Use NoRetry to disable retries.
+//
+// It is safe to call while requests are in flight;
+// requests already sent keep the policy they started with.
+func (c *GofileClient) SetRetryPolicy(policy RetryPolicy) {
+	c.retryMu.Lock()
+	defer c.retryMu.Unlock()
+	c.retryPolicy = policy
+}
+
+// noRetryContextKey marks the context of requests whose caller retries them itself.
+type noRetryContextKey struct{}
+
+// withoutRetries returns a context whose requests are sent once by do,
+// apart from waiting out rate limiting.
+func withoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryContextKey{}, true)
+}
+
+// retriesDisabled reports whether the caller of req retries it itself.
+func retriesDisabled(req *http.Request) bool {
+	disabled, _ := req.Context().Value(noRetryContextKey{}).(bool)
+	return disabled
+}
+
+// loadRetryPolicy returns the retry policy currently used by the client.
+func (c *GofileClient) loadRetryPolicy() RetryPolicy {
+	c.retryMu.RLock()
+	defer c.retryMu.RUnlock()
+	return c.retryPolicy
+}
+
+// retryable reports whether the policy allows retrying err.
+func (p RetryPolicy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// replayable reports whether req may be sent again after err without
+// the risk of the server applying it twice.
+func (p RetryPolicy) replayable(req *http.Request, err error) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return p.RetryNonIdempotent || !reachedServer(err)
+}
+
+// reachedServer reports whether the request that failed with err may have
+// been processed by the server. Rate limited requests and connection
+// failures were not.
+func reachedServer(err error) bool {
+	if errors.Is(err, ErrRateLimited) {
+		return false
+	}
+	var opErr *net.OpError
+	return !errors.As(err, &opErr) || opErr.Op != "dial"
+}
+
+// backoff returns the delay to wait after the given failed attempt, starting at 1.
+//
+// A Retry-After delay sent with a rate-limited response takes precedence
+// when it is longer than the computed backoff.
+func (p RetryPolicy) backoff(attempt int, err error) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		delay *= multiplier
+	}
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitter := min(p.Jitter, 1)
+		delay *= 1 - jitter + 2*jitter*rand.Float64()
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && time.Duration(delay) < apiErr.RetryAfter {
+		return apiErr.RetryAfter
+	}
+	return time.Duration(delay)
+}
+
+// rewindRequest returns a copy of req with a fresh body, ready to be sent again.
+// It returns false if the body cannot be replayed.
+func rewindRequest(req *http.Request) (*http.Request, bool, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, true, nil
+	}
+	if req.GetBody == nil {
+		return nil, false, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false, fmt.Errorf("rewinding request body: %w", err)
+	}
+	next := req.Clone(req.Context())
+	next.Body = body
+	return next, true, nil
+}
+
+// sleep waits for the given duration or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,122 @@
+package gofile_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	gofile "github.com/yaGatito/gofile-client"
+	"github.com/yaGatito/gofile-client/gofiletest"
+)
+
+func TestRetryIdempotentRequestOnServerError(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+	uploaded := uploadTestFile(t, client, srv, "a.txt", []byte("hello"))
+
+	srv.InjectFault(gofiletest.Fault{Kind: gofiletest.FaultServerError, Method: http.MethodGet, PathPrefix: "/contents/", Count: 2})
+	info, err := client.GetFileInfo(context.Background(), "", uploaded.Data.Id)
+	if err != nil {
+		t.Fatalf("GetFileInfo: %v", err)
+	}
+	if info.Data.Id != uploaded.Data.Id {
+		t.Errorf("got file %q, want %q", info.Data.Id, uploaded.Data.Id)
+	}
+	if got := recorder.count(http.MethodGet, "/contents/"); got != 3 {
+		t.Errorf("sent %d requests, want 3", got)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+
+	srv.InjectFault(gofiletest.Fault{Kind: gofiletest.FaultServerError, PathPrefix: "/accounts/getid"})
+	_, err := client.GetAccount(context.Background())
+	var apiErr *gofile.APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatus != http.StatusInternalServerError {
+		t.Fatalf("got error %v, want a 500 APIError", err)
+	}
+	if got := recorder.count(http.MethodGet, "/accounts/getid"); got != fastRetries.MaxAttempts {
+		t.Errorf("sent %d requests, want %d", got, fastRetries.MaxAttempts)
+	}
+}
+
+func TestRetrySkipsNonRetryableErrors(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+
+	_, err := client.GetFileInfo(context.Background(), "", "missing")
+	if !errors.Is(err, gofile.ErrNotFound) {
+		t.Fatalf("got error %v, want ErrNotFound", err)
+	}
+	if got := recorder.count(http.MethodGet, "/contents/"); got != 1 {
+		t.Errorf("sent %d requests, want 1", got)
+	}
+}
+
+func TestRetryDoesNotResendNonIdempotentRequests(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+
+	srv.InjectFault(gofiletest.Fault{Kind: gofiletest.FaultServerError, Method: http.MethodPost, PathPrefix: "/contents/createFolder", Count: 1})
+	_, err := client.CreateFolder(context.Background(), srv.RootFolderId(srv.Token()), "folder")
+	if err == nil {
+		t.Fatal("CreateFolder succeeded, want the injected error")
+	}
+	if got := recorder.count(http.MethodPost, "/contents/createFolder"); got != 1 {
+		t.Errorf("sent %d requests, want 1", got)
+	}
+}
+
+func TestRetryNonIdempotentRequestsWhenEnabled(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	policy := fastRetries
+	policy.RetryNonIdempotent = true
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()), gofile.WithRetryPolicy(policy))
+
+	srv.InjectFault(gofiletest.Fault{Kind: gofiletest.FaultServerError, Method: http.MethodPost, PathPrefix: "/contents/createFolder", Count: 1})
+	if _, err := client.CreateFolder(context.Background(), srv.RootFolderId(srv.Token()), "folder"); err != nil {
+		t.Fatalf("CreateFolder: %v", err)
+	}
+	if got := recorder.count(http.MethodPost, "/contents/createFolder"); got != 2 {
+		t.Errorf("sent %d requests, want 2", got)
+	}
+}
+
+func TestNoRetry(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()), gofile.WithRetryPolicy(gofile.NoRetry()))
+
+	srv.InjectFault(gofiletest.Fault{Kind: gofiletest.FaultHTMLPage, PathPrefix: "/accounts/getid", Count: 1})
+	_, err := client.GetAccount(context.Background())
+	if !errors.Is(err, gofile.ErrHTMLResponse) {
+		t.Fatalf("got error %v, want ErrHTMLResponse", err)
+	}
+	if got := recorder.count(http.MethodGet, "/accounts/getid"); got != 1 {
+		t.Errorf("sent %d requests, want 1", got)
+	}
+}
+
+func TestRetryNonIdempotentRequestsThatNeverReachedServer(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+	root := srv.RootFolderId(srv.Token())
+	// Connections to the closed server are refused before the request is sent.
+	srv.Close()
+
+	if _, err := client.CreateFolder(context.Background(), root, "folder"); err == nil {
+		t.Fatal("CreateFolder succeeded against a closed server")
+	}
+	if got := recorder.count(http.MethodPost, "/contents/createFolder"); got != fastRetries.MaxAttempts {
+		t.Errorf("sent %d requests, want %d", got, fastRetries.MaxAttempts)
+	}
+}
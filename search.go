@@ -0,0 +1,161 @@
+package gofile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxSearchPathDepth bounds the number of parent folders walked
+// when resolving the path of a search result.
+const maxSearchPathDepth = 64
+
+// SearchQuery filters the contents returned by Search.
+type SearchQuery struct {
+	// Name is the case-insensitive substring the content name must contain.
+	Name string
+	// Type restricts results to ContentTypeFile or ContentTypeFolder.
+	// An empty value matches both.
+	Type string
+}
+
+// SearchResult is a content matching a search, along with its slash-separated
+// path relative to the searched folder.
+type SearchResult struct {
+	FolderChild
+	Path string
+}
+
+func (s SearchResult) String() string {
+	return fmt.Sprintf("Path: %s; %s", s.Path, s.FolderChild)
+}
+
+// Search looks up files and folders in the tree under the specified folder.
+//
+// The folderId may be a concrete folder identifier or the special value "root".
+// When "root" is provided, the client's root folder ID is resolved automatically.
+//
+// Results are sorted by path.
+func (c *GofileClient) Search(ctx context.Context, folderId string, query SearchQuery) (_ []SearchResult, err error) {
+	defer c.observeOperation("Search", time.Now(), &err)
+	if folderId == "" {
+		return nil, fmt.Errorf("folderId is not specified")
+	}
+	if query.Name == "" {
+		return nil, fmt.Errorf("search name is not specified")
+	}
+	if query.Type != "" && query.Type != ContentTypeFile && query.Type != ContentTypeFolder {
+		return nil, fmt.Errorf("unknown content type %q", query.Type)
+	}
+
+	folderId, err = c.resolveFolderId(ctx, folderId)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.createGetSearchRequest(ctx, folderId, query.Name)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var searchResp searchResponseData
+	err = json.NewDecoder(resp.Body).Decode(&searchResp)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.ToLower(query.Name)
+	paths := newFolderPathResolver(c, folderId)
+	var results []SearchResult
+	for id, content := range searchResp.Data {
+		if content.Id == "" {
+			content.Id = id
+		}
+		if query.Type != "" && content.Type != query.Type {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(content.Name), name) {
+			continue
+		}
+
+		parentPath, err := paths.resolve(ctx, content.ParentFolderId)
+		if err != nil {
+			return nil, fmt.Errorf("resolving path of %q: %w", content.Id, err)
+		}
+		results = append(results, SearchResult{
+			FolderChild: content,
+			Path:        path.Join(parentPath, content.Name),
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Path < results[j].Path
+	})
+	return results, nil
+}
+
+// createGetSearchRequest builds an HTTP GET request for searching contents
+// by name within the specified folder tree.
+func (c *GofileClient) createGetSearchRequest(ctx context.Context, folderId, name string) (*http.Request, error) {
+	params := url.Values{}
+	params.Set("contentId", folderId)
+	params.Set("searchedString", name)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.contentsBaseURL()+"search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating 'search' request: %w", err)
+	}
+	return req, nil
+}
+
+// folderPathResolver resolves folder IDs to paths relative to a root folder,
+// caching every folder it has looked up.
+type folderPathResolver struct {
+	client *GofileClient
+	rootId string
+	paths  map[string]string
+}
+
+func newFolderPathResolver(client *GofileClient, rootId string) *folderPathResolver {
+	return &folderPathResolver{
+		client: client,
+		rootId: rootId,
+		paths:  map[string]string{rootId: ""},
+	}
+}
+
+// resolve returns the path of folderId relative to the root folder
+// by walking up its parent folders.
+func (r *folderPathResolver) resolve(ctx context.Context, folderId string) (string, error) {
+	var chain []GetFolderContentsResponseBody
+	for depth := 0; ; depth++ {
+		if p, ok := r.paths[folderId]; ok {
+			for i := len(chain) - 1; i >= 0; i-- {
+				p = path.Join(p, chain[i].Data.Name)
+				r.paths[chain[i].Data.Id] = p
+			}
+			return p, nil
+		}
+		if folderId == "" || depth >= maxSearchPathDepth {
+			return "", fmt.Errorf("folder is outside of the searched tree")
+		}
+
+		folder, err := r.client.GetFolderContents(ctx, folderId)
+		if err != nil {
+			return "", err
+		}
+		folder.Data.Id = folderId
+		chain = append(chain, folder)
+		folderId = folder.Data.ParentFolderId
+	}
+}
@@ -0,0 +1,97 @@
+package gofile_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	gofile "github.com/yaGatito/gofile-client"
+)
+
+func TestSearch(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	root := srv.RootFolderId(srv.Token())
+	reports := createTestFolder(t, client, root, "Reports")
+	archive := createTestFolder(t, client, reports, "archive")
+	uploadTestFile(t, client, srv, "report-2030.txt", []byte("a"))
+	uploadTestFileTo(t, client, archive, "REPORT-2029.txt", []byte("b"))
+	uploadTestFileTo(t, client, archive, "notes.txt", []byte("c"))
+
+	results, err := client.Search(context.Background(), "root", gofile.SearchQuery{Name: "report"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	var paths []string
+	for _, result := range results {
+		paths = append(paths, result.Path)
+	}
+	want := "Reports, Reports/archive/REPORT-2029.txt, report-2030.txt"
+	if got := strings.Join(paths, ", "); got != want {
+		t.Errorf("got paths %s, want %s", got, want)
+	}
+
+	files, err := client.Search(context.Background(), reports, gofile.SearchQuery{Name: "report", Type: gofile.ContentTypeFile})
+	if err != nil {
+		t.Fatalf("Search of files: %v", err)
+	}
+	if len(files) != 1 || files[0].Path != "archive/REPORT-2029.txt" || files[0].Type != gofile.ContentTypeFile {
+		t.Errorf("got %v, want archive/REPORT-2029.txt", files)
+	}
+}
+
+func TestSearchRejectsInvalidQueries(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	ctx := context.Background()
+
+	if _, err := client.Search(ctx, "", gofile.SearchQuery{Name: "a"}); err == nil {
+		t.Error("Search succeeded without a folder ID")
+	}
+	if _, err := client.Search(ctx, "root", gofile.SearchQuery{}); err == nil {
+		t.Error("Search succeeded without a name")
+	}
+	if _, err := client.Search(ctx, "root", gofile.SearchQuery{Name: "a", Type: "link"}); err == nil {
+		t.Error("Search succeeded with an unknown content type")
+	}
+}
+
+func TestSearchResultOutsideSearchedTree(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	client := newTestClient(t, srv, gofile.WithMiddleware(recorder.middleware()))
+	root := srv.RootFolderId(srv.Token())
+	searched := createTestFolder(t, client, root, "searched")
+	other := createTestFolder(t, client, root, "other")
+	recorder.stub(http.MethodGet, "/contents/search", fmt.Sprintf(
+		`{"status":"ok","data":{"f1":{"type":"file","name":"stray.txt","parentFolder":%q}}}`, other))
+
+	_, err := client.Search(context.Background(), searched, gofile.SearchQuery{Name: "stray"})
+	if err == nil || !strings.Contains(err.Error(), "outside of the searched tree") {
+		t.Errorf("got error %v, want a result outside of the searched tree", err)
+	}
+}
+
+func TestSearchPathDepthLimit(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	folders := []string{srv.RootFolderId(srv.Token())}
+	for i := range 70 {
+		folders = append(folders, createTestFolder(t, client, folders[i], fmt.Sprintf("d%d", i)))
+	}
+	uploadTestFileTo(t, client, folders[len(folders)-1], "deep.txt", []byte("deep"))
+
+	if _, err := client.Search(context.Background(), "root", gofile.SearchQuery{Name: "deep"}); err == nil {
+		t.Error("Search resolved a path deeper than the depth limit")
+	}
+
+	results, err := client.Search(context.Background(), folders[20], gofile.SearchQuery{Name: "deep"})
+	if err != nil {
+		t.Fatalf("Search within the depth limit: %v", err)
+	}
+	if len(results) != 1 || !strings.HasPrefix(results[0].Path, "d20/d21/") || !strings.HasSuffix(results[0].Path, "/d69/deep.txt") {
+		t.Errorf("got %v, want the path from d20 to deep.txt", results)
+	}
+}
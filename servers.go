@@ -0,0 +1,262 @@
+package gofile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	defaultUploadServerTTL          = 10 * time.Minute
+	defaultUploadServerProbeTimeout = 3 * time.Second
+	defaultUploadServerURLTemplate  = "https://%s.gofile.io/contents/uploadfile"
+)
+
+// Server is a GoFile store server.
+type Server struct {
+	Name string `json:"name"`
+	Zone string `json:"zone"`
+}
+
+// UploadServerSelection configures how the client picks the server files are uploaded to.
+type UploadServerSelection struct {
+	// Zone restricts discovery to servers of a zone, e.g. "eu" or "na".
+	// An empty zone accepts servers of every zone.
+	Zone string
+	// Probe measures the latency of every discovered server and picks
+	// the fastest healthy one. Otherwise the first discovered server is used.
+	Probe bool
+	// ProbeTimeout bounds a single latency probe. Defaults to 3 seconds.
+	ProbeTimeout time.Duration
+	// TTL is how long the selected server is reused. Defaults to 10 minutes.
+	TTL time.Duration
+	// URLTemplate builds the upload URL from a server name, using a single %s verb.
+	// Defaults to "https://%s.gofile.io/contents/uploadfile".
+	URLTemplate string
+}
+
+// GetServers lists the store servers currently accepting uploads.
+//
+// An empty zone lists servers of every zone.
+func (c *GofileClient) GetServers(ctx context.Context, zone string) (_ []Server, err error) {
+	defer c.observeOperation("GetServers", time.Now(), &err)
+	req, err := c.createGetServersRequest(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var getServersResp getServersResponseData
+	err = json.NewDecoder(resp.Body).Decode(&getServersResp)
+	if err != nil {
+		return nil, err
+	}
+	if zone == "" && len(getServersResp.Data.ServersAllZone) > 0 {
+		return getServersResp.Data.ServersAllZone, nil
+	}
+	return getServersResp.Data.Servers, nil
+}
+
+// createGetServersRequest builds an HTTP GET request for listing
+// the available store servers of the specified zone.
+func (c *GofileClient) createGetServersRequest(ctx context.Context, zone string) (*http.Request, error) {
+	endpoint := c.apiBaseURL + "/servers"
+	if zone != "" {
+		endpoint += "?" + url.Values{"zone": {zone}}.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating 'getServers' request: %w", err)
+	}
+	return req, nil
+}
+
+// uploadServerSelector discovers, caches and replaces the upload server.
+type uploadServerSelector struct {
+	selection UploadServerSelection
+
+	mu      sync.Mutex
+	server  string
+	expires time.Time
+	// failed holds servers that recently failed, until they may be picked again.
+	failed map[string]time.Time
+}
+
+func newUploadServerSelector(selection UploadServerSelection) *uploadServerSelector {
+	if selection.ProbeTimeout <= 0 {
+		selection.ProbeTimeout = defaultUploadServerProbeTimeout
+	}
+	if selection.TTL <= 0 {
+		selection.TTL = defaultUploadServerTTL
+	}
+	if selection.URLTemplate == "" {
+		selection.URLTemplate = defaultUploadServerURLTemplate
+	}
+	return &uploadServerSelector{
+		selection: selection,
+		failed:    make(map[string]time.Time),
+	}
+}
+
+// uploadEndpoint returns the URL to upload to and the name of the selected server.
+//
+// Without server selection, or when discovery fails, the configured upload URL
+// is returned with an empty server name.
+func (c *GofileClient) uploadEndpoint(ctx context.Context) (string, string) {
+	if c.uploadServers == nil {
+		return c.postFileEndpoint(), ""
+	}
+	server, err := c.uploadServers.get(ctx, c)
+	if err != nil {
+		c.logger.WarnContext(ctx, "Upload server selection failed, using the default upload URL", "endpoint", c.postFileEndpoint(), "error", err)
+		return c.postFileEndpoint(), ""
+	}
+	return c.uploadServers.url(server), server
+}
+
+// reportUploadFailure drops the selected server after a server-side
+// or transport failure, so that the next upload picks another one.
+func (c *GofileClient) reportUploadFailure(server string, err error) {
+	if c.uploadServers == nil || server == "" || !isServerFailure(err) {
+		return
+	}
+	c.logger.Warn("Upload server failed, it will be replaced", "server", server, "error", err)
+	c.uploadServers.markFailed(server)
+}
+
+// get returns the cached server, discovering a new one if the cache is empty or expired.
+//
+// Discovery runs without holding the lock, so that uploads to a cached server
+// are not blocked by a concurrent discovery.
+func (s *uploadServerSelector) get(ctx context.Context, c *GofileClient) (string, error) {
+	s.mu.Lock()
+	if s.server != "" && time.Now().Before(s.expires) {
+		server := s.server
+		s.mu.Unlock()
+		return server, nil
+	}
+	s.mu.Unlock()
+
+	servers, err := c.GetServers(ctx, s.selection.Zone)
+	if err != nil {
+		return "", fmt.Errorf("discovering upload servers: %w", err)
+	}
+	var candidates []string
+	for _, server := range servers {
+		if !s.isFailed(server.Name) {
+			candidates = append(candidates, server.Name)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no healthy upload server in zone %q", s.selection.Zone)
+	}
+
+	server := candidates[0]
+	if s.selection.Probe {
+		server, err = s.fastest(ctx, c.client, candidates)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// The server may have failed an upload while it was being probed.
+	if until, ok := s.failed[server]; !ok || time.Now().After(until) {
+		s.server = server
+		s.expires = time.Now().Add(s.selection.TTL)
+	}
+	return server, nil
+}
+
+// isFailed reports whether the server recently failed and may not be picked yet.
+func (s *uploadServerSelector) isFailed(server string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	until, ok := s.failed[server]
+	return ok && time.Now().Before(until)
+}
+
+// markFailed evicts the server from the cache and excludes it
+// from discovery for one TTL.
+func (s *uploadServerSelector) markFailed(server string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed[server] = time.Now().Add(s.selection.TTL)
+	if s.server == server {
+		s.server = ""
+	}
+}
+
+// url returns the upload URL of the server.
+func (s *uploadServerSelector) url(server string) string {
+	return fmt.Sprintf(s.selection.URLTemplate, server)
+}
+
+// fastest probes the servers concurrently, waits for every probe to finish
+// and returns the healthy server with the lowest latency.
+func (s *uploadServerSelector) fastest(ctx context.Context, client *http.Client, servers []string) (string, error) {
+	type probe struct {
+		server  string
+		latency time.Duration
+		err     error
+	}
+	probes := make(chan probe, len(servers))
+	for _, server := range servers {
+		go func(server string) {
+			latency, err := s.probe(ctx, client, server)
+			probes <- probe{server: server, latency: latency, err: err}
+		}(server)
+	}
+
+	best := probe{}
+	for range servers {
+		p := <-probes
+		if p.err != nil {
+			continue
+		}
+		if best.server == "" || p.latency < best.latency {
+			best = p
+		}
+	}
+	if best.server == "" {
+		return "", fmt.Errorf("no upload server answered the latency probe")
+	}
+	return best.server, nil
+}
+
+// probe measures the round trip of a HEAD request to the root of the server.
+// Any HTTP answer below 500 counts as healthy.
+func (s *uploadServerSelector) probe(ctx context.Context, client *http.Client, server string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.selection.ProbeTimeout)
+	defer cancel()
+
+	target, err := url.Parse(s.url(server))
+	if err != nil {
+		return 0, err
+	}
+	target.Path = "/"
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, target.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return 0, fmt.Errorf("probe of %s returned %s", server, resp.Status)
+	}
+	return time.Since(start), nil
+}
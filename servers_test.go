@@ -0,0 +1,163 @@
+package gofile_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gofile "github.com/yaGatito/gofile-client"
+	"github.com/yaGatito/gofile-client/gofiletest"
+)
+
+// newSelectingClient creates a client of srv discovering its upload server
+// among the servers listed by srv.
+func newSelectingClient(t *testing.T, srv *gofiletest.Server, recorder *requestRecorder, selection gofile.UploadServerSelection) *gofile.GofileClient {
+	t.Helper()
+	if selection.URLTemplate == "" {
+		selection.URLTemplate = srv.URL + "/%s/uploadfile"
+	}
+	return newTestClient(t, srv,
+		gofile.WithUploadServerSelection(selection),
+		gofile.WithMiddleware(recorder.middleware()),
+		gofile.WithRetryPolicy(gofile.NoRetry()),
+	)
+}
+
+func TestGetServers(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	eu := gofile.Server{Name: "store1", Zone: "eu"}
+	na := gofile.Server{Name: "store2", Zone: "na"}
+	srv.SetServers(eu, na)
+
+	servers, err := client.GetServers(context.Background(), "")
+	if err != nil {
+		t.Fatalf("GetServers: %v", err)
+	}
+	if !reflect.DeepEqual(servers, []gofile.Server{eu, na}) {
+		t.Errorf("got servers %v, want %v", servers, []gofile.Server{eu, na})
+	}
+
+	servers, err = client.GetServers(context.Background(), "na")
+	if err != nil {
+		t.Fatalf("GetServers of a zone: %v", err)
+	}
+	if !reflect.DeepEqual(servers, []gofile.Server{na}) {
+		t.Errorf("got servers %v, want %v", servers, []gofile.Server{na})
+	}
+}
+
+func TestUploadServerSelectionCachesServer(t *testing.T) {
+	srv := newTestServer(t)
+	srv.SetServers(gofile.Server{Name: "store1", Zone: "eu"}, gofile.Server{Name: "store2", Zone: "na"})
+	recorder := &requestRecorder{}
+	client := newSelectingClient(t, srv, recorder, gofile.UploadServerSelection{Zone: "na"})
+
+	uploadTestFile(t, client, srv, "a.txt", []byte("a"))
+	uploadTestFile(t, client, srv, "b.txt", []byte("b"))
+
+	if got := recorder.count(http.MethodGet, "/servers"); got != 1 {
+		t.Errorf("discovered servers %d times, want once", got)
+	}
+	if got := recorder.count(http.MethodPost, "/store2/uploadfile"); got != 2 {
+		t.Errorf("sent %d uploads to store2, the only server of the zone, want 2", got)
+	}
+}
+
+func TestUploadServerSelectionExpires(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	client := newSelectingClient(t, srv, recorder, gofile.UploadServerSelection{TTL: 10 * time.Millisecond})
+
+	uploadTestFile(t, client, srv, "a.txt", []byte("a"))
+	time.Sleep(20 * time.Millisecond)
+	uploadTestFile(t, client, srv, "b.txt", []byte("b"))
+
+	if got := recorder.count(http.MethodGet, "/servers"); got != 2 {
+		t.Errorf("discovered servers %d times, want again after the TTL", got)
+	}
+}
+
+func TestUploadServerSelectionReplacesFailedServer(t *testing.T) {
+	srv := newTestServer(t)
+	srv.SetServers(gofile.Server{Name: "store1", Zone: "eu"}, gofile.Server{Name: "store2", Zone: "eu"})
+	recorder := &requestRecorder{}
+	client := newSelectingClient(t, srv, recorder, gofile.UploadServerSelection{})
+	root := srv.RootFolderId(srv.Token())
+
+	srv.InjectFault(gofiletest.Fault{Kind: gofiletest.FaultServerError, PathPrefix: "/store1/uploadfile"})
+	if _, err := client.UploadFile(context.Background(), root, "a.txt", io.NopCloser(strings.NewReader("a"))); err == nil {
+		t.Fatal("upload to the failing server succeeded")
+	}
+	uploaded := uploadTestFile(t, client, srv, "b.txt", []byte("b"))
+
+	if got := recorder.count(http.MethodPost, "/store1/uploadfile"); got != 1 {
+		t.Errorf("sent %d uploads to the failing server, want 1", got)
+	}
+	if got := recorder.count(http.MethodPost, "/store2/uploadfile"); got != 1 {
+		t.Errorf("sent %d uploads to the replacement server, want 1", got)
+	}
+	if _, ok := srv.FileData(uploaded.Data.Id); !ok {
+		t.Error("the upload to the replacement server was not stored")
+	}
+}
+
+func TestUploadServerSelectionFallsBackToUploadURL(t *testing.T) {
+	srv := newTestServer(t)
+	recorder := &requestRecorder{}
+	client := newSelectingClient(t, srv, recorder, gofile.UploadServerSelection{})
+
+	srv.InjectFault(gofiletest.Fault{Kind: gofiletest.FaultServerError, PathPrefix: "/servers"})
+	uploadTestFile(t, client, srv, "a.txt", []byte("a"))
+
+	if got := recorder.count(http.MethodPost, "/uploadfile"); got != 1 {
+		t.Errorf("sent %d uploads to the configured upload URL, want 1", got)
+	}
+}
+
+func TestUploadServerSelectionProbe(t *testing.T) {
+	srv := newTestServer(t)
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Servers are named after the address of their httptest server,
+	// which the upload URL template turns back into a URL.
+	var unhealthyUploads atomic.Int32
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			unhealthyUploads.Add(1)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(unhealthy.Close)
+	healthy := httptest.NewServer(httputil.NewSingleHostReverseProxy(target))
+	t.Cleanup(healthy.Close)
+	srv.SetServers(
+		gofile.Server{Name: unhealthy.Listener.Addr().String(), Zone: "eu"},
+		gofile.Server{Name: healthy.Listener.Addr().String(), Zone: "eu"},
+	)
+
+	recorder := &requestRecorder{}
+	client := newSelectingClient(t, srv, recorder, gofile.UploadServerSelection{
+		Probe:       true,
+		URLTemplate: "http://%s/uploadfile",
+	})
+	uploaded := uploadTestFile(t, client, srv, "a.txt", []byte("a"))
+
+	if got := unhealthyUploads.Load(); got != 0 {
+		t.Errorf("sent %d uploads to the server failing its probe", got)
+	}
+	if _, ok := srv.FileData(uploaded.Data.Id); !ok {
+		t.Error("the upload through the healthy server was not stored")
+	}
+}
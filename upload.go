@@ -3,10 +3,13 @@ package gofile
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"strings"
+	"time"
 )
 
 const (
@@ -18,93 +21,229 @@ const (
 //
 // The folderId may be a concrete folder identifier or the special value "root".
 // When "root" is provided, the client's root folder ID is resolved automatically.
+// For a guest client "root" is the guest folder: the first upload creates it,
+// and the returned guest token and folder are reused by later uploads.
 //
 // The provided fileReader is fully consumed and closed by this method.
+//
+// The MD5 sum of the sent bytes is compared with the one returned by the server.
+// On mismatch the response is returned along with an *IntegrityError.
 func (c *GofileClient) UploadFile(
 	ctx context.Context,
 	folderId, fileName string,
 	fileReader io.ReadCloser,
-) (UploadFileResponseBody, error) {
+) (_ UploadFileResponseBody, err error) {
+	defer c.observeOperation("UploadFile", time.Now(), &err)
 
+	if fileReader == nil {
+		return UploadFileResponseBody{}, fmt.Errorf("fileReader is not specified")
+	}
 	if folderId == "" {
+		fileReader.Close()
 		return UploadFileResponseBody{}, fmt.Errorf("folderId is not specified")
 	}
 	if fileName == "" {
+		fileReader.Close()
 		return UploadFileResponseBody{}, fmt.Errorf("fileName is not specified")
 	}
-	if fileReader == nil {
-		return UploadFileResponseBody{}, fmt.Errorf("fileReader is not specified")
+
+	if c.guest && folderId == rootFolderIdPlaceholderConst {
+		return c.uploadGuestFile(ctx, fileName, fileReader)
 	}
 
-	req, err := c.createPostFileRequest(ctx, folderId, fileName, fileReader)
+	folderId, err = c.resolveFolderId(ctx, folderId)
 	if err != nil {
+		fileReader.Close()
 		return UploadFileResponseBody{}, err
 	}
-	resp, err := c.do(req)
+	return c.uploadFile(ctx, folderId, fileName, fileReader)
+}
+
+// uploadGuestFile uploads a file into the guest folder of the client.
+//
+// The first guest upload is sent without a folder, which makes GoFile create
+// a guest account; its token and root folder are captured for later uploads.
+// Concurrent first uploads are serialized so that only one guest account is created.
+func (c *GofileClient) uploadGuestFile(ctx context.Context, fileName string, fileReader io.ReadCloser) (UploadFileResponseBody, error) {
+	if _, guestFolderId := c.guestSession(); guestFolderId != "" {
+		return c.uploadFile(ctx, guestFolderId, fileName, fileReader)
+	}
+
+	c.guestUploadMu.Lock()
+	defer c.guestUploadMu.Unlock()
+
+	if _, guestFolderId := c.guestSession(); guestFolderId != "" {
+		return c.uploadFile(ctx, guestFolderId, fileName, fileReader)
+	}
+
+	result, digest, err := c.sendFile(ctx, "", fileName, fileReader)
 	if err != nil {
 		return UploadFileResponseBody{}, err
 	}
+	if result.Data.GuestToken == "" || result.Data.ParentFolderId == "" {
+		return result, fmt.Errorf("guest upload response has no guest token or folder")
+	}
+	// The session is captured before the integrity check, whose deletion
+	// of a corrupted upload must be authorized by the guest token.
+	c.setGuestSession(result.Data.GuestToken, result.Data.ParentFolderId)
+	c.logger.InfoContext(ctx, "Captured guest session", "folder_id", result.Data.ParentFolderId)
+
+	return result, c.verifyUpload(ctx, result, digest)
+}
+
+// uploadFile sends the upload request and verifies the uploaded file.
+// An empty folderId lets GoFile choose the destination folder.
+func (c *GofileClient) uploadFile(
+	ctx context.Context,
+	folderId, fileName string,
+	fileReader io.ReadCloser,
+) (UploadFileResponseBody, error) {
+
+	result, digest, err := c.sendFile(ctx, folderId, fileName, fileReader)
+	if err != nil {
+		return UploadFileResponseBody{}, err
+	}
+	return result, c.verifyUpload(ctx, result, digest)
+}
+
+// sendFile sends the upload request and decodes the response,
+// along with the digest of the sent bytes to verify it against.
+func (c *GofileClient) sendFile(
+	ctx context.Context,
+	folderId, fileName string,
+	fileReader io.ReadCloser,
+) (UploadFileResponseBody, *uploadDigest, error) {
+
+	uploadURL, server := c.uploadEndpoint(ctx)
+	req, digest, err := c.createPostFileRequest(ctx, uploadURL, folderId, fileName, fileReader)
+	if err != nil {
+		return UploadFileResponseBody{}, nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		c.reportUploadFailure(server, err)
+		return UploadFileResponseBody{}, nil, err
+	}
 	defer resp.Body.Close()
 
 	var result UploadFileResponseBody
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	if err != nil {
-		return UploadFileResponseBody{}, err
+		return UploadFileResponseBody{}, nil, err
 	}
-	return result, nil
+	return result, digest, nil
+}
+
+// verifyUpload compares the MD5 sum and size computed while streaming the file
+// with the values returned by the server.
+//
+// On mismatch it returns an *IntegrityError, after deleting the remote copy
+// if the client is configured to. Uploads whose response has no MD5 are not checked.
+func (c *GofileClient) verifyUpload(ctx context.Context, result UploadFileResponseBody, digest *uploadDigest) error {
+	select {
+	case <-digest.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if result.Data.Md5 == "" {
+		return nil
+	}
+
+	localMd5 := digest.counter.sum()
+	localSize := digest.counter.size
+	if strings.EqualFold(result.Data.Md5, localMd5) && (result.Data.Size == 0 || result.Data.Size == localSize) {
+		return nil
+	}
+
+	integrityErr := &IntegrityError{
+		ContentId:    result.Data.Id,
+		ExpectedMd5:  localMd5,
+		ActualMd5:    result.Data.Md5,
+		ExpectedSize: localSize,
+		ActualSize:   result.Data.Size,
+	}
+	if result.Data.Size == 0 {
+		integrityErr.ActualSize = localSize
+	}
+	if c.deleteCorruptUploads && result.Data.Id != "" {
+		deleted, err := c.DeleteContents(ctx, result.Data.Id)
+		if err != nil {
+			return errors.Join(integrityErr, fmt.Errorf("deleting corrupted upload: %w", err))
+		}
+		integrityErr.Deleted = len(deleted.Failed()) == 0
+	}
+	return integrityErr
+}
+
+// uploadDigest holds the MD5 sum and size of an upload body,
+// available once done is closed.
+type uploadDigest struct {
+	counter *md5Counter
+	done    chan struct{}
 }
 
 // createPostFileRequest constructs a streaming multipart/form-data
-// HTTP request for file upload.
+// HTTP request for file upload to the given upload URL.
 //
 // The request body is produced asynchronously using an io.Pipe to avoid
 // buffering the entire file in memory.
 //
+// The folderId field is omitted when folderId is empty.
 // The provided fileReader is consumed and closed during request body generation.
+// The MD5 sum and size of the file are computed on the fly into the returned digest.
 func (c *GofileClient) createPostFileRequest(
 	ctx context.Context,
-	folderId, fileName string,
+	uploadURL, folderId, fileName string,
 	fileReader io.ReadCloser,
-) (*http.Request, error) {
+) (*http.Request, *uploadDigest, error) {
+
+	tracker := c.newProgressTracker(ctx, OperationUpload, fileName, 0, readerSize(fileReader))
+	fileReader = withProgress(c.newThrottle(ctx, OperationUpload).reader(ctx, fileReader), tracker)
 
 	bodyReader, bodyWriter := io.Pipe()
 	writer := multipart.NewWriter(bodyWriter)
 
+	digest := &uploadDigest{counter: newMd5Counter(), done: make(chan struct{})}
+
 	go func() {
+		defer close(digest.done)
 		defer bodyWriter.Close()
-		err := writer.WriteField(folderIdAttribute, folderId)
-		if err != nil {
-			c.logger.Printf("failed to write 'folder ID' into multipart body: %v\n", err)
-			bodyWriter.CloseWithError(err)
-			return
+		defer fileReader.Close()
+		if folderId != "" {
+			err := writer.WriteField(folderIdAttribute, folderId)
+			if err != nil {
+				c.logger.ErrorContext(ctx, "Writing folder ID into multipart body", "error", err)
+				bodyWriter.CloseWithError(err)
+				return
+			}
 		}
 		part, err := writer.CreateFormFile(fileAttribute, fileName)
 		if err != nil {
-			c.logger.Printf("error creating form file for multipart writer: %v\n", err)
+			c.logger.ErrorContext(ctx, "Creating form file for multipart writer", "error", err)
 			bodyWriter.CloseWithError(err)
 			return
 		}
-		_, err = io.Copy(part, fileReader)
+		_, err = io.Copy(io.MultiWriter(part, digest.counter), fileReader)
 		if err != nil {
-			c.logger.Printf("failed to copy file into multipart body error: %v\n", err)
+			c.logger.ErrorContext(ctx, "Copying file into multipart body", "file_name", fileName, "error", err)
 			bodyWriter.CloseWithError(err)
 			return
 		}
-		err = fileReader.Close()
 		if err = writer.Close(); err != nil {
-			c.logger.Printf("closing resources error: %v\n", err)
+			c.logger.ErrorContext(ctx, "Closing multipart body", "error", err)
 			bodyWriter.CloseWithError(err)
 			return
 		}
 	}()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, postFileEndpoint, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, bodyReader)
 	if err != nil {
-		return nil, fmt.Errorf("creating post file request: %w", err)
+		bodyReader.Close()
+		return nil, nil, fmt.Errorf("creating post file request: %w", err)
 	}
 	req.Header.Set(contentTypeHeader, writer.FormDataContentType())
 
-	c.logger.Printf("Created file upload request for file %s to folder id %s\n", fileName, folderId)
+	c.logger.DebugContext(ctx, "Created file upload request", "file_name", fileName, "folder_id", folderId)
 
-	return req, nil
+	return req, digest, nil
 }
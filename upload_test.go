@@ -0,0 +1,154 @@
+package gofile_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	gofile "github.com/yaGatito/gofile-client"
+	"github.com/yaGatito/gofile-client/gofiletest"
+)
+
+func TestUploadFile(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	data := testData(256 << 10)
+
+	reader := newCloseRecorder(bytes.NewReader(data))
+	resp, err := client.UploadFile(context.Background(), srv.RootFolderId(srv.Token()), "data.bin", reader)
+	if err != nil {
+		t.Fatalf("UploadFile: %v", err)
+	}
+	reader.waitClosed(t)
+	if resp.Data.Name != "data.bin" || resp.Data.Size != int64(len(data)) {
+		t.Errorf("got file %q of %d bytes, want data.bin of %d bytes", resp.Data.Name, resp.Data.Size, len(data))
+	}
+	stored, ok := srv.FileData(resp.Data.Id)
+	if !ok || !bytes.Equal(stored, data) {
+		t.Error("stored bytes differ from the uploaded ones")
+	}
+}
+
+func TestUploadFileToRootPlaceholder(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+
+	resp, err := client.UploadFile(context.Background(), "root", "a.txt", io.NopCloser(strings.NewReader("hello")))
+	if err != nil {
+		t.Fatalf("UploadFile: %v", err)
+	}
+	if want := srv.RootFolderId(srv.Token()); resp.Data.ParentFolderId != want {
+		t.Errorf("uploaded into %q, want the root folder %q", resp.Data.ParentFolderId, want)
+	}
+}
+
+func TestUploadFileClosesReaderOnValidationError(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+
+	reader := newCloseRecorder(strings.NewReader("hello"))
+	if _, err := client.UploadFile(context.Background(), srv.RootFolderId(srv.Token()), "", reader); err == nil {
+		t.Fatal("UploadFile succeeded without a file name")
+	}
+	reader.waitClosed(t)
+}
+
+func TestUploadFileClosesReaderWhenRateLimited(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv)
+	root := srv.RootFolderId(srv.Token())
+
+	srv.InjectFault(gofiletest.Fault{Kind: gofiletest.FaultRateLimit, PathPrefix: "/uploadfile", Count: 1, RetryAfter: time.Minute})
+	_, err := client.UploadFile(context.Background(), root, "a.txt", io.NopCloser(strings.NewReader("hello")))
+	if !errors.Is(err, gofile.ErrRateLimited) {
+		t.Fatalf("got error %v, want ErrRateLimited", err)
+	}
+
+	// The upload class is now paused: the next upload is never sent.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	reader := newCloseRecorder(strings.NewReader("hello"))
+	if _, err := client.UploadFile(ctx, root, "b.txt", reader); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want context.DeadlineExceeded", err)
+	}
+	reader.waitClosed(t)
+}
+
+func TestUploadFileIntegrityMismatch(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv,
+		gofile.WithMiddleware(corruptUploadMd5),
+		gofile.WithDeleteCorruptUploads(true),
+	)
+
+	resp, err := client.UploadFile(context.Background(), srv.RootFolderId(srv.Token()), "a.txt", io.NopCloser(strings.NewReader("hello")))
+	var integrityErr *gofile.IntegrityError
+	if !errors.As(err, &integrityErr) {
+		t.Fatalf("got error %v, want an *IntegrityError", err)
+	}
+	if !integrityErr.Deleted {
+		t.Error("corrupted upload was not reported as deleted")
+	}
+	if _, ok := srv.FileData(resp.Data.Id); ok {
+		t.Error("corrupted upload was kept on the server")
+	}
+}
+
+func TestGuestUploadIntegrityMismatchDeletesCorruptCopy(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestGuestClient(t, srv,
+		gofile.WithMiddleware(corruptUploadMd5),
+		gofile.WithDeleteCorruptUploads(true),
+	)
+
+	resp, err := client.UploadFile(context.Background(), "root", "a.txt", io.NopCloser(strings.NewReader("hello")))
+	var integrityErr *gofile.IntegrityError
+	if !errors.As(err, &integrityErr) {
+		t.Fatalf("got error %v, want an *IntegrityError", err)
+	}
+	if !integrityErr.Deleted {
+		t.Error("corrupted guest upload was not reported as deleted")
+	}
+	if _, ok := srv.FileData(resp.Data.Id); ok {
+		t.Error("corrupted guest upload was kept on the server")
+	}
+
+	// The guest session was captured despite the mismatch.
+	contents, err := client.GetFolderContents(context.Background(), "root")
+	if err != nil {
+		t.Fatalf("GetFolderContents: %v", err)
+	}
+	if contents.Data.Id != resp.Data.ParentFolderId {
+		t.Errorf("guest root is %q, want the folder of the first upload %q", contents.Data.Id, resp.Data.ParentFolderId)
+	}
+}
+
+// corruptUploadMd5 replaces the MD5 sum of upload responses,
+// as if the server had received different bytes.
+func corruptUploadMd5(next gofile.Doer) gofile.Doer {
+	return gofile.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := next.Do(req)
+		if err != nil || !strings.HasSuffix(req.URL.Path, "/uploadfile") {
+			return resp, err
+		}
+		defer resp.Body.Close()
+		var body map[string]any
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		body["data"].(map[string]any)["md5"] = "00000000000000000000000000000000"
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(encoded))
+		resp.ContentLength = int64(len(encoded))
+		return resp, nil
+	})
+}